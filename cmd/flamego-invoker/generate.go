@@ -0,0 +1,334 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// directive is the comment that annotates handlers to generate invokers for.
+const directive = "//flamego:invoker"
+
+// signature is the signature of handlers.
+type signature struct {
+	Params   []string // The list of parameter types.
+	Variadic bool     // Whether the last parameter is variadic.
+	Results  []string // The list of result types.
+}
+
+// FuncType returns the function type of the signature.
+func (s signature) FuncType() string {
+	params := make([]string, len(s.Params))
+	copy(params, s.Params)
+	if s.Variadic {
+		params[len(params)-1] = "..." + strings.TrimPrefix(params[len(params)-1], "[]")
+	}
+
+	typ := "func(" + strings.Join(params, ", ") + ")"
+	switch len(s.Results) {
+	case 0:
+	case 1:
+		typ += " " + s.Results[0]
+	default:
+		typ += " (" + strings.Join(s.Results, ", ") + ")"
+	}
+	return typ
+}
+
+// invoker is an inject.FastInvoker implementation to be generated.
+type invoker struct {
+	Name string
+	signature
+}
+
+var invokerTemplate = template.Must(template.New("").Parse(`// Code generated by flamego-invoker. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+
+func init() {
+{{- range .Invokers}}
+	flamego.RegisterFastInvoker(func(h {{.FuncType}}) inject.FastInvoker { return {{.Name}}(h) })
+{{- end}}
+}
+{{range .Invokers}}
+var _ inject.FastInvoker = (*{{.Name}})(nil)
+
+type {{.Name}} {{.FuncType}}
+
+func (invoke {{.Name}}) Invoke(args []interface{}) ([]reflect.Value, error) {
+{{- range $i, $p := .Params}}
+	arg{{$i}}, _ := args[{{$i}}].({{$p}})
+{{- end}}
+	{{if .Results}}{{range $i, $_ := .Results}}{{if $i}}, {{end}}ret{{$i}}{{end}} := {{end -}}
+	invoke({{range $i, $_ := .Params}}{{if $i}}, {{end}}arg{{$i}}{{end}}{{if .Variadic}}...{{end}})
+{{- if .Results}}
+	return []reflect.Value{
+	{{- range $i, $_ := .Results}}
+		reflect.ValueOf(&ret{{$i}}).Elem(),
+	{{- end}}
+	}, nil
+{{- else}}
+	return nil, nil
+{{- end}}
+}
+{{end}}`))
+
+// generate scans Go files in the directory, and returns the source code of
+// invokers for annotated handlers. It returns nil if no handler is annotated.
+// The output file is excluded from scanning.
+func generate(dir, output string) ([]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "read directory")
+	}
+
+	fset := token.NewFileSet()
+	var pkgName string
+	var files []*ast.File // Sorted by file names
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == output {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse file")
+		}
+		if pkgName != "" && pkgName != file.Name.Name {
+			return nil, errors.Errorf("found packages %s and %s in %q", pkgName, file.Name.Name, dir)
+		}
+		pkgName = file.Name.Name
+		files = append(files, file)
+	}
+
+	imports := map[string]string{ // Keys are names, values are paths
+		"reflect": "reflect",
+		"flamego": "github.com/flamego/flamego",
+		"inject":  "github.com/flamego/flamego/inject",
+	}
+	var invokers []invoker
+	seen := make(map[string]bool) // Keys are function types
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !hasDirective(fn.Doc) {
+				continue
+			}
+			if fn.Type.TypeParams != nil {
+				return nil, errors.Errorf("%s: generic function %s is not supported", fset.Position(fn.Pos()), fn.Name.Name)
+			}
+
+			sig, err := parseSignature(fset, file, fn.Type, imports)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s: parse signature of %s", fset.Position(fn.Pos()), fn.Name.Name)
+			}
+			if seen[sig.FuncType()] {
+				continue
+			}
+			seen[sig.FuncType()] = true
+			invokers = append(invokers, invoker{
+				Name:      "invoker" + strconv.Itoa(len(invokers)),
+				signature: sig,
+			})
+		}
+	}
+	if len(invokers) == 0 {
+		return nil, nil
+	}
+
+	// Group imports of the standard library and others, same as goimports.
+	var std, others []string
+	for name, p := range imports {
+		spec := strconv.Quote(p)
+		if name != path.Base(p) {
+			spec = name + " " + spec
+		}
+
+		if strings.Contains(strings.Split(p, "/")[0], ".") {
+			others = append(others, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+	for _, specs := range [][]string{std, others} {
+		sort.Slice(specs, func(i, j int) bool {
+			return importPath(specs[i]) < importPath(specs[j])
+		})
+	}
+	specs := std
+	if len(others) > 0 {
+		specs = append(append(specs, ""), others...)
+	}
+
+	var buf bytes.Buffer
+	err = invokerTemplate.Execute(&buf, map[string]interface{}{
+		"Package":  pkgName,
+		"Imports":  specs,
+		"Invokers": invokers,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "execute template")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "format source")
+	}
+	return src, nil
+}
+
+// hasDirective returns true if the comment group contains the directive.
+func hasDirective(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if strings.TrimSpace(c.Text) == directive {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSignature parses the signature of the function type, and records
+// packages that are referenced by the signature to imports.
+func parseSignature(fset *token.FileSet, file *ast.File, typ *ast.FuncType, imports map[string]string) (signature, error) {
+	var sig signature
+	typeString := func(expr ast.Expr) (string, error) {
+		err := collectImports(file, expr, imports)
+		if err != nil {
+			return "", err
+		}
+
+		var buf bytes.Buffer
+		err = format.Node(&buf, fset, expr)
+		if err != nil {
+			return "", errors.Wrap(err, "format type")
+		}
+		return buf.String(), nil
+	}
+
+	for _, field := range typ.Params.List {
+		expr := field.Type
+		if ellipsis, ok := expr.(*ast.Ellipsis); ok {
+			sig.Variadic = true
+			expr = &ast.ArrayType{Elt: ellipsis.Elt}
+		}
+
+		t, err := typeString(expr)
+		if err != nil {
+			return signature{}, err
+		}
+		for i := 0; i < max(len(field.Names), 1); i++ {
+			sig.Params = append(sig.Params, t)
+		}
+	}
+
+	if typ.Results != nil {
+		for _, field := range typ.Results.List {
+			t, err := typeString(field.Type)
+			if err != nil {
+				return signature{}, err
+			}
+			for i := 0; i < max(len(field.Names), 1); i++ {
+				sig.Results = append(sig.Results, t)
+			}
+		}
+	}
+	return sig, nil
+}
+
+// collectImports records packages that are referenced by the expression to
+// imports, and returns an error if a name refers to different packages.
+func collectImports(file *ast.File, expr ast.Expr, imports map[string]string) error {
+	var err error
+	ast.Inspect(expr, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok || err != nil {
+			return err == nil
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		p, ok := importPathOf(file, ident.Name)
+		if !ok {
+			err = errors.Errorf("unknown package %q", ident.Name)
+			return false
+		}
+		if existing, ok := imports[ident.Name]; ok && existing != p {
+			err = errors.Errorf("package name %q refers to both %q and %q", ident.Name, existing, p)
+			return false
+		}
+		imports[ident.Name] = p
+		return false
+	})
+	return err
+}
+
+// importPathOf returns the import path of the package name in the file.
+func importPathOf(file *ast.File, name string) (string, bool) {
+	for _, spec := range file.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		if spec.Name != nil {
+			if spec.Name.Name == name {
+				return p, true
+			}
+			continue
+		}
+		if defaultPackageName(p) == name {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// defaultPackageName returns the conventional package name of the import path,
+// i.e. the last element that is not a major version suffix (e.g. "v2").
+func defaultPackageName(importPath string) string {
+	elems := strings.Split(importPath, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' {
+		if _, err := strconv.Atoi(name[1:]); err == nil {
+			name = elems[len(elems)-2]
+		}
+	}
+	name = strings.TrimPrefix(name, "go-")
+	if i := strings.Index(name, "."); i > 0 {
+		name = name[:i] // e.g. "gopkg.in/yaml.v3"
+	}
+	return name
+}
+
+// importPath returns the import path of the import spec.
+func importPath(spec string) string {
+	p, _ := strconv.Unquote(spec[strings.Index(spec, `"`):])
+	return p
+}
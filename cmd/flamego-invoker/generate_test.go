@@ -0,0 +1,47 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := filepath.Join("testdata", "handlers")
+	got, err := generate(dir, "flamego_invokers.go")
+	require.NoError(t, err)
+
+	want, err := os.ReadFile(filepath.Join(dir, "flamego_invokers.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+
+	t.Run("no annotated handlers", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644)
+		require.NoError(t, err)
+
+		got, err := generate(dir, "flamego_invokers.go")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestDefaultPackageName(t *testing.T) {
+	tests := map[string]string{
+		"net/http":                      "http",
+		"charm.land/log/v2":             "log",
+		"gopkg.in/yaml.v3":              "yaml",
+		"github.com/mattn/go-runewidth": "runewidth",
+		"github.com/flamego/flamego":    "flamego",
+	}
+	for importPath, want := range tests {
+		assert.Equal(t, want, defaultPackageName(importPath), importPath)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Command flamego-invoker generates inject.FastInvoker implementations for
+// handlers in a package, and registers them via flamego.RegisterFastInvoker so
+// that matching handlers are wrapped automatically to avoid the cost of
+// reflection.
+//
+// Handlers are top-level functions and methods that are annotated with the
+// "//flamego:invoker" directive in their doc comments:
+//
+//	//flamego:invoker
+//	func GetUser(c flamego.Context, db *sql.DB) (int, error) {
+//	    ...
+//	}
+//
+// Add the following line to any file of the package and run `go generate`:
+//
+//	//go:generate go run github.com/flamego/flamego/cmd/flamego-invoker
+//
+// Usage:
+//
+//	flamego-invoker [-dir <package directory>] [-o <output file>]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "The directory of the package to scan")
+	output := flag.String("o", "flamego_invokers.go", "The name of the output file in the package directory")
+	flag.Parse()
+
+	src, err := generate(*dir, *output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "flamego-invoker:", err)
+		os.Exit(1)
+	}
+	if src == nil {
+		fmt.Fprintln(os.Stderr, "flamego-invoker: no handler is annotated with //flamego:invoker")
+		return
+	}
+
+	err = os.WriteFile(filepath.Join(*dir, *output), src, 0644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "flamego-invoker:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	flog "charm.land/log/v2"
+
+	"github.com/flamego/flamego"
+)
+
+type UserHandler struct{}
+
+//flamego:invoker
+func (h *UserHandler) Get(c flamego.Context, db *sql.DB) (int, error) {
+	return http.StatusOK, nil
+}
+
+//flamego:invoker
+func List(db *sql.DB, r flamego.Render) {}
+
+// Duplicate signatures share the same invoker.
+//
+//flamego:invoker
+func Delete(c flamego.Context, db *sql.DB) (status int, err error) {
+	return http.StatusNoContent, nil
+}
+
+//flamego:invoker
+func Log(logger *flog.Logger, keyvals ...interface{}) string {
+	return ""
+}
+
+// Handlers without the directive are ignored.
+func Ignored(w http.ResponseWriter) {}
@@ -5,14 +5,16 @@
 package flamego
 
 import (
+	gocontext "context"
 	"fmt"
 	"math"
 	"net/http"
 	"net/url"
 	"reflect"
-	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/flamego/flamego/inject"
 	"github.com/flamego/flamego/internal/route"
@@ -23,12 +25,50 @@ import (
 //
 //go:generate go-mockgen -f github.com/flamego/flamego -i Context -o mock_context_test.go
 type Context interface {
-	inject.Injector
+	inject.Container
 	// ResponseWriter returns the ResponseWriter in current context.
 	ResponseWriter() ResponseWriter
 	// Request returns the Request in current context.
 	Request() *Request
 
+	// Context returns the context.Context of the request, whose Value method also
+	// looks up the per-request key-value store when the key is not found in the
+	// request context. The same value is injected to handlers that ask for a
+	// context.Context.
+	//
+	// NOTE: The Context itself does not fully implement the context.Context
+	// because the Value method is taken by the inject.Injector, but it provides
+	// all other methods of the context.Context.
+	Context() gocontext.Context
+	// Deadline returns the deadline of the context.Context of the request, see
+	// context.Context for details.
+	Deadline() (deadline time.Time, ok bool)
+	// Done returns the channel that is closed when the context.Context of the
+	// request is cancelled, see context.Context for details.
+	Done() <-chan struct{}
+	// Err returns the error of the context.Context of the request after it is
+	// cancelled, see context.Context for details.
+	Err() error
+	// WithContext replaces the context.Context of the request, e.g. to add a
+	// deadline or a tracing span. Subsequent handlers observe the new context via
+	// both Request().Context() and Context().
+	WithContext(ctx gocontext.Context)
+
+	// SetValue sets the value of the given key in the per-request key-value
+	// store, which lives as long as the request and is safe for concurrent use,
+	// e.g. by goroutines spawned by handlers. Values of allow-listed keys are
+	// included as fields of logs by Logger and Recovery, see LoggerOptions and
+	// RecoveryOptions.
+	//
+	// NOTE: The name Set is taken by the inject.Injector.
+	SetValue(key, val interface{})
+	// GetValue returns the value of the given key in the per-request key-value
+	// store, and whether the key is present.
+	GetValue(key interface{}) (interface{}, bool)
+	// Values returns a copy of all key-value pairs in the per-request key-value
+	// store.
+	Values() map[interface{}]interface{}
+
 	// URLPath builds the "path" portion of URL with given pairs of values. To
 	// include the optional segment, pass `"withOptional", "true"`.
 	//
@@ -37,12 +77,53 @@ type Context interface {
 
 	// Next runs the next handler in the context chain.
 	Next()
+	// Abort prevents remaining handlers in the context chain (including the
+	// Flame.Action) from being invoked, but does not stop the current handler.
+	// Handlers that have called Next still resume after Next returns.
+	Abort()
+	// AbortWithStatus calls Abort and writes the given status code to the
+	// response.
+	AbortWithStatus(code int)
+	// IsAborted returns true if the context chain has been aborted.
+	IsAborted() bool
+	// Error reports the error to the error handler (see Flame.ErrorHandler) and
+	// aborts the remaining handlers in the context chain. It is a no-op when the
+	// error is nil.
+	Error(err error)
+	// HandlerTraces returns traces of handlers that have been invoked in the
+	// context chain, or nil if tracing is not enabled (see Flame.TraceHandlers).
+	// Durations of handlers that have not returned are up to the time of the call.
+	HandlerTraces() []HandlerTrace
+	// After allows for a function to be called once the whole context chain has
+	// finished, even if a middleware never called Next or a handler panicked.
+	// Multiple calls to this method will stack up functions, and functions will be
+	// called in the LIFO manner. Functions that are added by these functions are
+	// called right after the function that adds them. The final status code and
+	// size of the response are available via ResponseWriter, and the panic value
+	// recovered by Recovery is available via PanicValue.
+	After(fn func())
 	// RemoteAddr extracts and returns the remote IP address from following attempts
 	// in sequence:
 	//  - "X-Real-IP" request header
-	//  - "X-Forwarded-For" request header
+	//  - The first entry of the "X-Forwarded-For" request header
 	//  - http.Request.RemoteAddr field
+	//
+	// When trusted proxies are set via Flame.TrustedProxies, it returns the
+	// address of the client that is reported by the outermost trusted proxy in the
+	// "Forwarded", "X-Forwarded-For" or "X-Real-IP" request header instead, and
+	// the http.Request.RemoteAddr field when the direct peer is not trusted.
 	RemoteAddr() string
+	// Scheme returns the scheme ("http" or "https") that is used by the client,
+	// which respects the "Forwarded" and "X-Forwarded-Proto" request headers
+	// added by trusted proxies (see Flame.TrustedProxies).
+	Scheme() string
+	// Host returns the host that is requested by the client, which respects the
+	// "Forwarded" and "X-Forwarded-Host" request headers added by trusted proxies
+	// (see Flame.TrustedProxies).
+	Host() string
+	// BaseURL returns the scheme and host that are used by the client, e.g.
+	// "https://example.com".
+	BaseURL() string
 	// Redirect sends a redirection to the response to the given location. If the
 	// `status` is not given, the http.StatusFound is used.
 	Redirect(location string, status ...int)
@@ -103,6 +184,16 @@ type internalContext interface {
 
 	// setAction sets the final handler in the context chain.
 	setAction(Handler)
+	// setTrustedProxies sets the list of trusted proxies.
+	setTrustedProxies(trustedProxies)
+	// setStatusHandlers sets the handlers for responses of specific status codes.
+	setStatusHandlers(map[int][]Handler)
+	// setTraceOptions sets the options of tracing handlers, nil to disable.
+	setTraceOptions(*TraceOptions)
+	// setFallbackHandlers sets the number of handlers at the end of the context
+	// chain (excluding the action) whose responses are fallbacks, which are
+	// replaced by status handlers of the response status.
+	setFallbackHandlers(int)
 	// run executes all handlers in the context chain.
 	run()
 }
@@ -112,15 +203,29 @@ type internalContext interface {
 type Params map[string]string
 
 type context struct {
-	inject.Injector
+	inject.Container
 
 	handlers []Handler // The list of handlers to be executed.
 	action   Handler   // The last action handler to be executed.
 	index    int       // The index of the current handler that is being executed.
+	aborted  bool      // Whether the context chain has been aborted.
+	afters   []func()  // The list of functions to be called after the context chain.
+
+	handlingError bool // Whether an error is being handled by the error handler.
 
-	responseWriter ResponseWriter // The http.ResponseWriter wrapper for the coming request.
-	request        *Request       // The http.Request wrapper for the coming request.
-	params         Params         // The values of bind parameters for the coming request.
+	responseWriter *responseWriter   // The http.ResponseWriter wrapper for the coming request.
+	request        *Request          // The http.Request wrapper for the coming request.
+	params         Params            // The values of bind parameters for the coming request.
+	trustedProxies trustedProxies    // The list of trusted proxies, nil when not configured.
+	statusHandlers map[int][]Handler // The handlers for responses of specific status codes.
+	fallbacks      int               // The number of handlers at the end of the chain whose responses are fallbacks.
+
+	traceOptions *TraceOptions  // The options of tracing handlers, nil when disabled.
+	traces       []HandlerTrace // The traces of invoked handlers.
+	traceFrames  []traceFrame   // The stack of handlers that are being traced.
+
+	valuesMu sync.RWMutex                // The lock for the values.
+	values   map[interface{}]interface{} // The per-request key-value store.
 
 	// urlPath is used to build URL path for a route.
 	urlPath urlPather
@@ -134,17 +239,25 @@ func newContext(w http.ResponseWriter, r *http.Request, params route.Params, han
 		r.SetPathValue(name, value)
 	}
 
+	rw := &responseWriter{
+		ResponseWriter: w,
+		method:         r.Method,
+		// The HEAD request falls back to handlers of the GET method when the matched
+		// route is of the GET method, see router.ServeHTTP.
+		headFallback: r.Method == http.MethodHead && strings.HasPrefix(r.Pattern, http.MethodGet+" "),
+	}
 	c := &context{
-		Injector:       inject.New(),
+		Container:      inject.New(),
 		handlers:       handlers,
-		responseWriter: NewResponseWriter(r.Method, w),
+		responseWriter: rw,
 		request:        &Request{Request: r},
 		params:         Params(params),
 		urlPath:        urlPath,
 	}
 	c.MapTo(c, (*Context)(nil))
-	c.MapTo(c.responseWriter, (*http.ResponseWriter)(nil))
+	c.MapTo(rw, (*http.ResponseWriter)(nil))
 	c.Map(r)
+	c.MapTo(c.Context(), (*gocontext.Context)(nil))
 	return c
 }
 
@@ -156,19 +269,142 @@ func (c *context) Request() *Request {
 	return c.request
 }
 
+func (c *context) Context() gocontext.Context {
+	return &requestContext{Context: c.request.Context(), c: c}
+}
+
+func (c *context) Deadline() (deadline time.Time, ok bool) {
+	return c.request.Context().Deadline()
+}
+
+func (c *context) Done() <-chan struct{} {
+	return c.request.Context().Done()
+}
+
+func (c *context) Err() error {
+	return c.request.Context().Err()
+}
+
+func (c *context) WithContext(ctx gocontext.Context) {
+	if ctx == nil {
+		panic("nil context")
+	}
+
+	c.request.Request = c.request.WithContext(ctx)
+	c.Map(c.request.Request)
+	c.MapTo(c.Context(), (*gocontext.Context)(nil))
+}
+
+func (c *context) SetValue(key, val interface{}) {
+	c.valuesMu.Lock()
+	defer c.valuesMu.Unlock()
+	if c.values == nil {
+		c.values = make(map[interface{}]interface{})
+	}
+	c.values[key] = val
+}
+
+func (c *context) GetValue(key interface{}) (interface{}, bool) {
+	c.valuesMu.RLock()
+	defer c.valuesMu.RUnlock()
+	v, ok := c.values[key]
+	return v, ok
+}
+
+func (c *context) Values() map[interface{}]interface{} {
+	c.valuesMu.RLock()
+	defer c.valuesMu.RUnlock()
+	values := make(map[interface{}]interface{}, len(c.values))
+	for k, v := range c.values {
+		values[k] = v
+	}
+	return values
+}
+
+// Value returns the value of the given key in the per-request key-value store
+// of the context as T. It returns false when the key is absent or the value is
+// not a T.
+//
+// For example:
+//
+//	c.SetValue("user", user)
+//	user, ok := flamego.Value[*User](c, "user")
+func Value[T any](c Context, key interface{}) (T, bool) {
+	v, ok := c.GetValue(key)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	t, ok := v.(T)
+	return t, ok
+}
+
+// requestContext is the context.Context of the request that also looks up the
+// per-request key-value store.
+type requestContext struct {
+	gocontext.Context
+	c *context
+}
+
+func (ctx *requestContext) Value(key interface{}) interface{} {
+	if v := ctx.Context.Value(key); v != nil {
+		return v
+	}
+	v, _ := ctx.c.GetValue(key)
+	return v
+}
+
 func (c *context) URLPath(name string, pairs ...string) string {
 	return c.urlPath(name, pairs...)
 }
 
 func (c *context) Next() {
 	c.index++
-	c.run()
+	if c.traceOptions != nil && len(c.traceFrames) > 0 {
+		defer c.exitNext(c.enterNext())
+	}
+	c.runHandlers()
+}
+
+func (c *context) Abort() {
+	c.aborted = true
+}
+
+func (c *context) AbortWithStatus(code int) {
+	c.Abort()
+	c.ResponseWriter().WriteHeader(code)
+}
+
+func (c *context) IsAborted() bool {
+	return c.aborted
+}
+
+func (c *context) After(fn func()) {
+	c.afters = append(c.afters, fn)
 }
 
 func (c *context) setAction(h Handler) {
 	c.action = h
 }
 
+func (c *context) setTrustedProxies(proxies trustedProxies) {
+	c.trustedProxies = proxies
+}
+
+func (c *context) setFallbackHandlers(n int) {
+	c.fallbacks = n
+}
+
+func (c *context) setStatusHandlers(handlers map[int][]Handler) {
+	c.statusHandlers = handlers
+	if len(handlers) > 0 {
+		c.responseWriter.interceptStatus = func(status int) bool {
+			_, ok := handlers[status]
+			return ok
+		}
+	}
+}
+
 // ordinalize ordinalizes the number by adding the ordinal to the number.
 func ordinalize(number int) string {
 	abs := int(math.Abs(float64(number)))
@@ -192,7 +428,51 @@ func ordinalize(number int) string {
 }
 
 func (c *context) run() {
-	for c.index <= len(c.handlers) {
+	// Close values that are created by providers for the request after all
+	// handlers and hooks are done with them.
+	defer func() {
+		err := c.Dispose()
+		if err == nil {
+			return
+		}
+
+		if logger := contextLogger(c); logger != nil {
+			logger.Error("Failed to dispose values of the request", "error", err)
+		}
+	}()
+	defer func() {
+		// Functions may add more functions, which are called before the rest.
+		for len(c.afters) > 0 {
+			fn := c.afters[len(c.afters)-1]
+			c.afters = c.afters[:len(c.afters)-1]
+			fn()
+		}
+	}()
+
+	c.runHandlers()
+
+	// Handlers may map their own wrappers of the http.ResponseWriter, which
+	// eventually write to the one of the context, thus the intercepted and delayed
+	// statuses are always handled by the one of the context.
+	w := c.responseWriter
+	// Replace the response that has the intercepted status and an empty (or
+	// fallback) body with the status handlers.
+	if status, ok := w.takeIntercepted(); ok {
+		c.handlers = c.statusHandlers[status]
+		c.action = nil
+		c.fallbacks = 0
+		c.index = 0
+		c.aborted = false
+		c.runHandlers()
+		w.restoreIntercepted(status)
+	}
+	w.writeHeadStatus(true)
+}
+
+// runHandlers executes handlers in the context chain starting from the current
+// index.
+func (c *context) runHandlers() {
+	for c.index <= len(c.handlers) && !c.aborted {
 		// Break out when the request context has been cancelled.
 		select {
 		case <-c.Request().Context().Done():
@@ -212,18 +492,22 @@ func (c *context) run() {
 			return
 		}
 
-		vals, err := c.Invoke(h)
-		if err != nil {
-			panic(fmt.Sprintf("unable to invoke the %s handler [%s:%T]: %v",
-				ordinalize(c.index), runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name(), h, err))
+		frame := -1
+		if c.traceOptions != nil {
+			frame = c.startTrace(h)
+		}
+
+		// Only responses of fallback handlers themselves are fallbacks, e.g. not
+		// ones of global middleware in the chain of NotFound handlers.
+		fallback := c.index < len(c.handlers) && c.index >= len(c.handlers)-c.fallbacks
+		if fallback || c.responseWriter.writingFallback {
+			c.responseWriter.writeFallback(fallback, func() { c.invokeHandler(h) })
+		} else {
+			c.invokeHandler(h)
 		}
-		c.index++
 
-		// If the handler returned something, write it to the response.
-		if len(vals) > 0 {
-			ev := c.Value(reflect.TypeOf(ReturnHandler(nil)))
-			handleReturn := ev.Interface().(ReturnHandler)
-			handleReturn(c, vals)
+		if frame >= 0 {
+			c.endTrace(frame)
 		}
 
 		if c.ResponseWriter().Written() {
@@ -232,22 +516,22 @@ func (c *context) run() {
 	}
 }
 
-func (c *context) RemoteAddr() string {
-	addr := c.Request().Header.Get("X-Real-IP")
-	if addr != "" {
-		return addr
-	}
-
-	addr = c.Request().Header.Get("X-Forwarded-For")
-	if addr != "" {
-		return addr
+// invokeHandler invokes the handler at the current index and writes its return
+// values to the response.
+func (c *context) invokeHandler(h Handler) {
+	vals, err := c.Invoke(h)
+	if err != nil {
+		panic(fmt.Sprintf("unable to invoke the %s handler [%s:%T]: %v",
+			ordinalize(c.index), handlerName(h), h, err))
 	}
+	c.index++
 
-	addr = c.Request().RemoteAddr
-	if i := strings.LastIndex(addr, ":"); i > -1 {
-		addr = addr[:i]
+	// If the handler returned something, write it to the response.
+	if len(vals) > 0 {
+		ev := c.Value(reflect.TypeOf(ReturnHandler(nil)))
+		handleReturn := ev.Interface().(ReturnHandler)
+		handleReturn(c, vals)
 	}
-	return addr
 }
 
 func (c *context) Redirect(location string, status ...int) {
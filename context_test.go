@@ -13,7 +13,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -34,6 +36,113 @@ func TestContext_RequestContextCancel(t *testing.T) {
 	f.ServeHTTP(resp, req)
 }
 
+func TestContext_Context(t *testing.T) {
+	type ctxKey struct{}
+
+	f := NewWithLogger(&bytes.Buffer{})
+	f.Get("/",
+		func(c Context) {
+			ctx, cancel := gocontext.WithTimeout(c.Context(), time.Minute)
+			c.WithContext(gocontext.WithValue(ctx, ctxKey{}, "flamego"))
+			c.Next()
+			cancel()
+		},
+		func(c Context, ctx gocontext.Context, r *http.Request) string {
+			deadline, ok := ctx.Deadline()
+			assert.True(t, ok)
+			cDeadline, ok := c.Deadline()
+			assert.True(t, ok)
+			assert.Equal(t, deadline, cDeadline)
+			assert.Equal(t, ctx.Done(), c.Done())
+			assert.Nil(t, c.Err())
+			assert.Equal(t, "flamego", ctx.Value(ctxKey{}))
+			assert.Equal(t, "flamego", c.Context().Value(ctxKey{}))
+			assert.Equal(t, "flamego", c.Request().Context().Value(ctxKey{}))
+			assert.Equal(t, "flamego", r.Context().Value(ctxKey{}))
+			return "ok"
+		},
+	)
+
+	resp := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	assert.Nil(t, err)
+
+	f.ServeHTTP(resp, req)
+
+	assert.Equal(t, "ok", resp.Body.String())
+
+	t.Run("cancelled", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Get("/", func(c Context) {
+			ctx, cancel := gocontext.WithCancel(c.Context())
+			c.WithContext(ctx)
+			cancel()
+
+			<-c.Done()
+			assert.Equal(t, gocontext.Canceled, c.Err())
+		})
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+	})
+}
+
+func TestContext_Values(t *testing.T) {
+	type ctxKey struct{}
+
+	f := NewWithLogger(&bytes.Buffer{})
+	f.Get("/",
+		func(c Context) {
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					c.SetValue(i, i)
+				}(i)
+			}
+			wg.Wait()
+
+			c.SetValue("user", "flamego")
+			c.SetValue(ctxKey{}, 1)
+		},
+		func(c Context, ctx gocontext.Context) {
+			v, ok := c.GetValue("user")
+			assert.True(t, ok)
+			assert.Equal(t, "flamego", v)
+
+			_, ok = c.GetValue("404")
+			assert.False(t, ok)
+
+			user, ok := Value[string](c, "user")
+			assert.True(t, ok)
+			assert.Equal(t, "flamego", user)
+
+			_, ok = Value[int](c, "user")
+			assert.False(t, ok)
+
+			assert.Equal(t, 1, ctx.Value(ctxKey{}))
+			assert.Equal(t, 1, c.Context().Value(ctxKey{}))
+			assert.Nil(t, c.Request().Context().Value(ctxKey{}))
+
+			values := c.Values()
+			assert.Len(t, values, 12)
+			values["user"] = "modified"
+			user, _ = Value[string](c, "user")
+			assert.Equal(t, "flamego", user)
+		},
+	)
+
+	resp := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	assert.Nil(t, err)
+
+	f.ServeHTTP(resp, req)
+}
+
 func TestContext_URLPath(t *testing.T) {
 	f := NewWithLogger(&bytes.Buffer{})
 	f.Get("/params/{string}/{int}",
@@ -76,6 +185,125 @@ func TestContext_Next(t *testing.T) {
 	assert.Equal(t, "foobarfoo2", buf.String())
 }
 
+func TestContext_Abort(t *testing.T) {
+	t.Run("abort", func(t *testing.T) {
+		var buf bytes.Buffer
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Use(func(c Context) {
+			buf.WriteString("foo")
+			c.Next()
+			assert.True(t, c.IsAborted())
+			buf.WriteString("foo2")
+		})
+		f.Action(func() { buf.WriteString("action") })
+		f.Get("/",
+			func(c Context) {
+				assert.False(t, c.IsAborted())
+				c.Abort()
+				buf.WriteString("bar")
+			},
+			func() { buf.WriteString("baz") },
+		)
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "foobarfoo2", buf.String())
+	})
+
+	t.Run("abort with status", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Get("/",
+			func(c Context) { c.AbortWithStatus(http.StatusUnauthorized) },
+			func() { assert.Fail(t, "should not be called") },
+		)
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+}
+
+func TestContext_After(t *testing.T) {
+	t.Run("LIFO", func(t *testing.T) {
+		var buf bytes.Buffer
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Use(func(c Context) {
+			c.After(func() {
+				buf.WriteString("after1 ")
+				assert.Equal(t, http.StatusCreated, c.ResponseWriter().Status())
+				assert.Equal(t, 5, c.ResponseWriter().Size())
+			})
+		})
+		f.Get("/",
+			func(c Context) {
+				c.After(func() { buf.WriteString("after2 ") })
+				c.Next()
+				buf.WriteString("next ")
+			},
+			func() (int, string) {
+				buf.WriteString("handler ")
+				return http.StatusCreated, "hello"
+			},
+		)
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, "handler next after2 after1 ", buf.String())
+	})
+
+	t.Run("added by functions", func(t *testing.T) {
+		var buf bytes.Buffer
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Get("/", func(c Context) {
+			c.After(func() { buf.WriteString("after1 ") })
+			c.After(func() {
+				buf.WriteString("after2 ")
+				c.After(func() {
+					buf.WriteString("nested1 ")
+					c.After(func() { buf.WriteString("nested2 ") })
+				})
+			})
+		})
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, "after2 nested1 nested2 after1 ", buf.String())
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		called := false
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Get("/",
+			func(c Context) { c.After(func() { called = true }) },
+			func() { panic("here is a panic!") },
+		)
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		assert.PanicsWithValue(t, "here is a panic!", func() { f.ServeHTTP(resp, req) })
+		assert.True(t, called)
+	})
+}
+
 func TestContext_RemoteAddr(t *testing.T) {
 	f := NewWithLogger(&bytes.Buffer{})
 	f.Get("/", func(c Context) string {
@@ -969,3 +1197,25 @@ func TestOrdinalize(t *testing.T) {
 	assert.Equal(t, "-111th", ordinalize(-111))
 	assert.Equal(t, "5th", ordinalize(5))
 }
+
+// BenchmarkContext_Invoke exercises handlers that are invoked via reflection
+// and whose return values are handled by return handlers.
+func BenchmarkContext_Invoke(b *testing.B) {
+	f := NewWithLogger(&bytes.Buffer{})
+	f.Use(func(c Context) { c.Next() })
+	f.Get("/", func(r *http.Request, w http.ResponseWriter) string { return "ok" })
+	f.Get("/status", func(r *http.Request) (int, string) { return http.StatusOK, "ok" })
+
+	for name, path := range map[string]string{"string": "/", "status and string": "/status"} {
+		b.Run(name, func(b *testing.B) {
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			assert.Nil(b, err)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				f.ServeHTTP(httptest.NewRecorder(), req)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrMissingValue is returned when the requested value is absent.
+var ErrMissingValue = errors.New("missing value")
+
+// ValueError describes a failure of decoding a bind parameter or URL parameter.
+type ValueError struct {
+	// Source is where the value comes from, either "param" or "query".
+	Source string
+	// Name is the name of the bind parameter or URL parameter.
+	Name string
+	// Field is the name of the struct field that is being decoded into, empty when
+	// not decoding into a struct.
+	Field string
+	// Value is the raw value that is failed to decode.
+	Value string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ValueError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("decode %s %q into field %q: %v", e.Source, e.Name, e.Field, e.Err)
+	}
+	return fmt.Sprintf("decode %s %q: %v", e.Source, e.Name, e.Err)
+}
+
+func (e *ValueError) Unwrap() error {
+	return e.Err
+}
+
+// ValueErrors is a list of ValueError that are aggregated while decoding
+// values into a struct.
+type ValueErrors []*ValueError
+
+func (errs ValueErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Param returns the value of the given bind parameter decoded as T. Supported
+// types are strings, bools, integers, floats, time.Duration, and any type that
+// implements encoding.TextUnmarshaler (e.g. time.Time in RFC 3339 format). It
+// returns a *ValueError wrapping ErrMissingValue when the bind parameter is
+// absent.
+func Param[T any](c Context, name string) (T, error) {
+	var v T
+	s, ok := c.Params()[name]
+	if !ok {
+		return v, &ValueError{Source: "param", Name: name, Err: ErrMissingValue}
+	}
+
+	err := decodeValue(reflect.ValueOf(&v).Elem(), s)
+	if err != nil {
+		return v, &ValueError{Source: "param", Name: name, Value: s, Err: err}
+	}
+	return v, nil
+}
+
+// Query returns the value of the given URL parameter decoded as T. See Param
+// for supported types. It returns a *ValueError wrapping ErrMissingValue when
+// the URL parameter is absent.
+func Query[T any](c Context, name string) (T, error) {
+	var v T
+	vals, ok := c.Request().URL.Query()[name]
+	if !ok || len(vals) == 0 {
+		return v, &ValueError{Source: "query", Name: name, Err: ErrMissingValue}
+	}
+
+	err := decodeValue(reflect.ValueOf(&v).Elem(), vals[0])
+	if err != nil {
+		return v, &ValueError{Source: "query", Name: name, Value: vals[0], Err: err}
+	}
+	return v, nil
+}
+
+// Decode fills fields of the struct pointed by `v` with values of bind
+// parameters and URL parameters based on the "param" and "query" struct tags
+// respectively. See Param for supported field types, slices of supported types
+// are also supported for URL parameters to collect all values. Fields of absent
+// values are left untouched, and fields of embedded structs are decoded as if
+// they were fields of the outer struct.
+//
+// All failures of decoding are aggregated and returned as ValueErrors.
+//
+// For example:
+//
+//	type form struct {
+//	    Owner  string   `param:"owner"`
+//	    Page   int      `query:"page"`
+//	    Labels []string `query:"label"`
+//	}
+//	var f form
+//	err := flamego.Decode(c, &f)
+func Decode(c Context, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("decode target must be a pointer to struct, but got %T", v)
+	}
+
+	var errs ValueErrors
+	decodeStruct(rv.Elem(), c.Params(), c.Request().URL.Query(), &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func decodeStruct(v reflect.Value, params Params, query map[string][]string, errs *ValueErrors) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			decodeStruct(fv, params, query, errs)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name, ok := field.Tag.Lookup("param"); ok {
+			s, ok := params[name]
+			if !ok {
+				continue
+			}
+
+			err := decodeValue(fv, s)
+			if err != nil {
+				*errs = append(*errs, &ValueError{Source: "param", Name: name, Field: field.Name, Value: s, Err: err})
+			}
+		} else if name, ok := field.Tag.Lookup("query"); ok {
+			vals, ok := query[name]
+			if !ok || len(vals) == 0 {
+				continue
+			}
+
+			if fv.Kind() == reflect.Slice && !implementsTextUnmarshaler(fv) {
+				slice := reflect.MakeSlice(fv.Type(), len(vals), len(vals))
+				for j, s := range vals {
+					err := decodeValue(slice.Index(j), s)
+					if err != nil {
+						*errs = append(*errs, &ValueError{Source: "query", Name: name, Field: field.Name, Value: s, Err: err})
+					}
+				}
+				fv.Set(slice)
+				continue
+			}
+
+			err := decodeValue(fv, vals[0])
+			if err != nil {
+				*errs = append(*errs, &ValueError{Source: "query", Name: name, Field: field.Name, Value: vals[0], Err: err})
+			}
+		}
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// implementsTextUnmarshaler returns true if the pointer to the value implements
+// encoding.TextUnmarshaler.
+func implementsTextUnmarshaler(v reflect.Value) bool {
+	return v.CanAddr() && v.Addr().Type().Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem())
+}
+
+// decodeValue decodes the string into the settable value.
+func decodeValue(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeValue(v.Elem(), s)
+	}
+
+	if implementsTextUnmarshaler(v) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return errors.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
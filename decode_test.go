@@ -0,0 +1,197 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDecodeContext(t *testing.T, params Params, rawURL string) Context {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	require.NoError(t, err)
+
+	c := NewMockContext()
+	c.ParamsFunc.SetDefaultReturn(params)
+	c.RequestFunc.SetDefaultReturn(&Request{Request: req})
+	return c
+}
+
+func TestParam(t *testing.T) {
+	c := newDecodeContext(t,
+		Params{
+			"id":      "42",
+			"zero":    "0",
+			"garbage": "abc",
+			"ratio":   "0.5",
+			"enabled": "true",
+			"timeout": "1m30s",
+			"date":    "2021-12-24T00:00:00Z",
+			"ip":      "127.0.0.1",
+			"small":   "300",
+		},
+		"/",
+	)
+
+	t.Run("int", func(t *testing.T) {
+		v, err := Param[int](c, "id")
+		require.NoError(t, err)
+		assert.Equal(t, 42, v)
+
+		v, err = Param[int](c, "zero")
+		require.NoError(t, err)
+		assert.Equal(t, 0, v)
+
+		_, err = Param[int](c, "garbage")
+		assert.EqualError(t, err, `decode param "garbage": strconv.ParseInt: parsing "abc": invalid syntax`)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		_, err := Param[uint8](c, "small")
+		assert.EqualError(t, err, `decode param "small": strconv.ParseUint: parsing "300": value out of range`)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := Param[int](c, "404")
+		assert.True(t, errors.Is(err, ErrMissingValue))
+
+		var verr *ValueError
+		require.True(t, errors.As(err, &verr))
+		assert.Equal(t, "param", verr.Source)
+		assert.Equal(t, "404", verr.Name)
+	})
+
+	t.Run("float", func(t *testing.T) {
+		v, err := Param[float64](c, "ratio")
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, v)
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		v, err := Param[bool](c, "enabled")
+		require.NoError(t, err)
+		assert.True(t, v)
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		v, err := Param[time.Duration](c, "timeout")
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Second, v)
+	})
+
+	t.Run("time", func(t *testing.T) {
+		v, err := Param[time.Time](c, "date")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC), v)
+	})
+
+	t.Run("text unmarshaler", func(t *testing.T) {
+		v, err := Param[net.IP](c, "ip")
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1", v.String())
+	})
+
+	t.Run("pointer", func(t *testing.T) {
+		v, err := Param[*int](c, "id")
+		require.NoError(t, err)
+		assert.Equal(t, 42, *v)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := Param[[]int](c, "id")
+		assert.EqualError(t, err, `decode param "id": unsupported type []int`)
+	})
+}
+
+func TestQuery(t *testing.T) {
+	c := newDecodeContext(t, nil, "/?page=2&page=3&empty=&garbage=abc")
+
+	v, err := Query[int](c, "page")
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	_, err = Query[int](c, "empty")
+	assert.EqualError(t, err, `decode query "empty": strconv.ParseInt: parsing "": invalid syntax`)
+
+	_, err = Query[int](c, "garbage")
+	assert.EqualError(t, err, `decode query "garbage": strconv.ParseInt: parsing "abc": invalid syntax`)
+
+	_, err = Query[int](c, "404")
+	assert.True(t, errors.Is(err, ErrMissingValue))
+}
+
+func TestDecode(t *testing.T) {
+	type Pagination struct {
+		Page    int `query:"page"`
+		PerPage int `query:"per_page"`
+	}
+	type form struct {
+		Pagination
+		Owner   string        `param:"owner"`
+		ID      *int64        `param:"id"`
+		Labels  []string      `query:"label"`
+		Since   time.Time     `query:"since"`
+		Timeout time.Duration `query:"timeout"`
+		Missing string        `query:"missing"`
+		Ignored string
+		private string `query:"private"` //nolint:unused
+	}
+
+	t.Run("good", func(t *testing.T) {
+		c := newDecodeContext(t,
+			Params{"owner": "flamego", "id": "1"},
+			"/?page=2&per_page=20&label=bug&label=help+wanted&since=2021-12-24T00:00:00Z&timeout=5s&private=1",
+		)
+
+		f := form{Missing: "untouched"}
+		err := Decode(c, &f)
+		require.NoError(t, err)
+
+		id := int64(1)
+		want := form{
+			Pagination: Pagination{Page: 2, PerPage: 20},
+			Owner:      "flamego",
+			ID:         &id,
+			Labels:     []string{"bug", "help wanted"},
+			Since:      time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC),
+			Timeout:    5 * time.Second,
+			Missing:    "untouched",
+		}
+		assert.Equal(t, want, f)
+	})
+
+	t.Run("aggregated errors", func(t *testing.T) {
+		c := newDecodeContext(t,
+			Params{"owner": "flamego", "id": "abc"},
+			"/?page=two&timeout=5",
+		)
+
+		var f form
+		err := Decode(c, &f)
+
+		var errs ValueErrors
+		require.True(t, errors.As(err, &errs))
+		require.Len(t, errs, 3)
+		assert.Equal(t, "Page", errs[0].Field)
+		assert.Equal(t, "ID", errs[1].Field)
+		assert.Equal(t, "Timeout", errs[2].Field)
+		assert.EqualError(t, err, `decode query "page" into field "Page": strconv.ParseInt: parsing "two": invalid syntax; `+
+			`decode param "id" into field "ID": strconv.ParseInt: parsing "abc": invalid syntax; `+
+			`decode query "timeout" into field "Timeout": time: missing unit in duration "5"`)
+		assert.Equal(t, "flamego", f.Owner)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		c := newDecodeContext(t, nil, "/")
+		err := Decode(c, form{})
+		assert.EqualError(t, err, "decode target must be a pointer to struct, but got flamego.form")
+	})
+}
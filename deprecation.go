@@ -0,0 +1,111 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"charm.land/log/v2"
+)
+
+// DeprecationOptions contains options for the Route.Deprecated.
+type DeprecationOptions struct {
+	// Date is the time when the route was deprecated, which is responded with the
+	// "Deprecation" header as defined in RFC 9745. Default is to respond with
+	// "true" when not set.
+	Date time.Time
+	// Sunset is the time when the route is expected to become unavailable, which
+	// is responded with the "Sunset" header as defined in RFC 8594. Default is not
+	// set.
+	Sunset time.Time
+	// Successor is the URL of the successor version of the route, which is
+	// responded with the "Link" header using the "successor-version" relation.
+	// Default is not set.
+	Successor string
+	// Documentation is the URL of the human-readable deprecation information,
+	// which is responded with the "Link" header using the "deprecation" relation.
+	// Default is not set.
+	Documentation string
+	// GoneAfterSunset indicates whether to respond with http.StatusGone instead of
+	// invoking handlers of the route once the Sunset has passed.
+	GoneAfterSunset bool
+	// LogEvery specifies to log one of every n hits of the route. Default is 1,
+	// which logs every hit. Use a negative value to disable logging.
+	LogEvery int
+}
+
+// Deprecated marks the route (including its aliases) as deprecated. Responses
+// of the route carry the "Deprecation" header, and the "Sunset" and "Link"
+// headers when configured, which are set right before the response is written
+// via ResponseWriter.Before. Hits of the route are logged with the Flame logger
+// at the sampling rate of the LogEvery option.
+//
+// For example:
+//
+//	f.Get("/v1/users", ...).Deprecated(flamego.DeprecationOptions{
+//	    Sunset:    time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+//	    Successor: "/v2/users",
+//	})
+func (r *Route) Deprecated(opts ...DeprecationOptions) *Route {
+	var opt DeprecationOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.LogEvery == 0 {
+		opt.LogEvery = 1
+	}
+
+	deprecation := "true"
+	if !opt.Date.IsZero() {
+		deprecation = "@" + strconv.FormatInt(opt.Date.Unix(), 10)
+	}
+
+	var links []string
+	if opt.Successor != "" {
+		links = append(links, "<"+opt.Successor+`>; rel="successor-version"`)
+	}
+	if opt.Documentation != "" {
+		links = append(links, "<"+opt.Documentation+`>; rel="deprecation"`)
+	}
+
+	setHeaders := func(w http.ResponseWriter) {
+		w.Header().Set("Deprecation", deprecation)
+		if !opt.Sunset.IsZero() {
+			w.Header().Set("Sunset", opt.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if len(links) > 0 {
+			w.Header().Add("Link", strings.Join(links, ", "))
+		}
+	}
+
+	var hits atomic.Int64
+	handler := LoggerInvoker(func(c Context, logger *log.Logger) {
+		if opt.LogEvery > 0 && (hits.Add(1)-1)%int64(opt.LogEvery) == 0 {
+			logger.WithPrefix("Deprecated").Warn("Deprecated route is requested",
+				"method", c.Request().Method,
+				"path", c.Request().RequestURI,
+				"route", c.Param("route"),
+				"remote", c.RemoteAddr(),
+			)
+		}
+
+		if opt.GoneAfterSunset && !opt.Sunset.IsZero() && time.Now().After(opt.Sunset) {
+			setHeaders(c.ResponseWriter())
+			c.ResponseWriter().WriteHeader(http.StatusGone)
+			return
+		}
+
+		c.ResponseWriter().Before(func(w ResponseWriter) {
+			setHeaders(w)
+		})
+	})
+
+	r.handlers = append([]Handler{handler}, r.handlers...)
+	return r
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoute_Deprecated(t *testing.T) {
+	t.Run("headers", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Get("/v1/users", func() string { return "users" }).
+			Alias("/users").
+			Deprecated(DeprecationOptions{
+				Date:          time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				Sunset:        time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC),
+				Successor:     "/v2/users",
+				Documentation: "https://flamego.dev/deprecations",
+			})
+
+		for _, path := range []string{"/v1/users", "/users"} {
+			t.Run(path, func(t *testing.T) {
+				resp := httptest.NewRecorder()
+				req, err := http.NewRequest(http.MethodGet, path, nil)
+				require.NoError(t, err)
+
+				f.ServeHTTP(resp, req)
+
+				assert.Equal(t, http.StatusOK, resp.Code)
+				assert.Equal(t, "users", resp.Body.String())
+				assert.Equal(t, "@1767225600", resp.Header().Get("Deprecation"))
+				assert.Equal(t, "Thu, 01 Jan 2099 00:00:00 GMT", resp.Header().Get("Sunset"))
+				assert.Equal(t, `</v2/users>; rel="successor-version", <https://flamego.dev/deprecations>; rel="deprecation"`, resp.Header().Get("Link"))
+			})
+		}
+	})
+
+	t.Run("default options", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Get("/", func() string { return "home" }).Deprecated()
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		require.NoError(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, "true", resp.Header().Get("Deprecation"))
+		assert.Empty(t, resp.Header().Get("Sunset"))
+		assert.Empty(t, resp.Header().Get("Link"))
+	})
+
+	t.Run("gone after sunset", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Get("/", func() string { return "home" }).Deprecated(DeprecationOptions{
+			Sunset:          time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			GoneAfterSunset: true,
+		})
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		require.NoError(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusGone, resp.Code)
+		assert.Empty(t, resp.Body.String())
+		assert.Equal(t, "Wed, 01 Jan 2020 00:00:00 GMT", resp.Header().Get("Sunset"))
+	})
+
+	t.Run("log sampling", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			logEvery int
+			want     int
+		}{
+			{name: "every hit", logEvery: 0, want: 4},
+			{name: "one of every two hits", logEvery: 2, want: 2},
+			{name: "disabled", logEvery: -1, want: 0},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				var buf bytes.Buffer
+				f := NewWithLogger(&buf)
+				f.Get("/", func() {}).Deprecated(DeprecationOptions{LogEvery: test.logEvery})
+
+				for i := 0; i < 4; i++ {
+					req, err := http.NewRequest(http.MethodGet, "/", nil)
+					require.NoError(t, err)
+					f.ServeHTTP(httptest.NewRecorder(), req)
+				}
+				assert.Equal(t, test.want, strings.Count(buf.String(), "Deprecated route is requested"))
+			})
+		}
+	})
+}
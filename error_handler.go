@@ -0,0 +1,143 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// errorHandler is a service that handles errors that are returned by handlers
+// or reported via Context.Error.
+type errorHandler func(Context, error)
+
+// statusCoder is an error that carries the HTTP status code of the response.
+type statusCoder interface {
+	StatusCode() int
+}
+
+// statusError is an error with the HTTP status code of the response.
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+func (e *statusError) StatusCode() int {
+	return e.status
+}
+
+// errorStatus returns the HTTP status code of the error, which is the result of
+// the first error in the chain that implements `StatusCode() int`, or
+// http.StatusInternalServerError if none.
+func errorStatus(err error) int {
+	var sc statusCoder
+	if errors.As(err, &sc) {
+		return sc.StatusCode()
+	}
+	return http.StatusInternalServerError
+}
+
+// defaultErrorHandler writes the status code of the error to the response, and
+// the error message in development mode or the status text otherwise. Errors
+// that are (or wrap) a *Problem are written as problem details objects.
+func defaultErrorHandler(c Context, err error) {
+	w := c.ResponseWriter()
+	if w.Written() {
+		return
+	}
+
+	var p *Problem
+	if errors.As(err, &p) {
+		p = p.withDefaults()
+		p.Status = errorStatus(err) // Respect the status code of the outer errors
+		writeFallback(w, func() { writeProblem(w, c.Request().Request, contextLogger(c), p) })
+		return
+	}
+
+	status := errorStatus(err)
+	body := http.StatusText(status)
+	if Env() == EnvTypeDev {
+		body = err.Error()
+	}
+
+	writeFallback(w, func() {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		}
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+// ErrorHandler sets the handler to handle errors that are returned by handlers
+// or reported via Context.Error, and panics if the handler is not a callable
+// function. Services are injected to the handler, including the error itself
+// as the `error`. Use the errors.As to check whether the error implements the
+// `StatusCode() int` for the status code of the response.
+//
+// The default handler responds with the status code of the error (or
+// http.StatusInternalServerError if it does not implement `StatusCode() int`),
+// and the error message in development mode or the status text otherwise.
+// Errors that are (or wrap) a *Problem are responded as problem details
+// objects.
+//
+// For example:
+//
+//	f.ErrorHandler(func(c flamego.Context, err error, logger *log.Logger) (int, string) {
+//	    logger.Error("Failed to handle request", "error", err)
+//	    return http.StatusInternalServerError, "Something went wrong"
+//	})
+func (f *Flame) ErrorHandler(h Handler) {
+	h = validateAndWrapHandler(h, nil)
+	f.errorHandler = h
+	f.Map(errorHandler(func(c Context, err error) {
+		c.MapTo(err, (*error)(nil))
+		vals, invokeErr := c.Invoke(h)
+		if invokeErr != nil {
+			panic(fmt.Sprintf("unable to invoke the error handler [%s:%T]: %v", handlerName(h), h, invokeErr))
+		}
+
+		// If the handler returned something, write it to the response.
+		if len(vals) > 0 {
+			ev := c.Value(reflect.TypeOf(ReturnHandler(nil)))
+			handleReturn := ev.Interface().(ReturnHandler)
+			handleReturn(c, vals)
+		}
+	}))
+}
+
+func (c *context) Error(err error) {
+	if err == nil {
+		return
+	}
+	c.Abort()
+
+	// Fall back to the default handler for errors that are reported during
+	// handling an error to avoid infinite recursion.
+	if c.handlingError {
+		defaultErrorHandler(c, err)
+		return
+	}
+	c.handlingError = true
+	defer func() { c.handlingError = false }()
+
+	handle := defaultErrorHandler
+	if v := c.Value(reflect.TypeOf(errorHandler(nil))); v.IsValid() {
+		handle = v.Interface().(errorHandler)
+	}
+	handle(c, err)
+}
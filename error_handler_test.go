@@ -0,0 +1,171 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"charm.land/log/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testStatusError struct {
+	status int
+}
+
+func (e testStatusError) Error() string { return fmt.Sprintf("status %d", e.status) }
+
+func (e testStatusError) StatusCode() int { return e.status }
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      EnvType
+		handler  Handler
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "returned error",
+			handler: func() error {
+				return errors.New("secret detail")
+			},
+			wantCode: http.StatusInternalServerError,
+			wantBody: "secret detail",
+		},
+		{
+			name: "returned error in production",
+			env:  EnvTypeProd,
+			handler: func() error {
+				return errors.New("secret detail")
+			},
+			wantCode: http.StatusInternalServerError,
+			wantBody: "Internal Server Error",
+		},
+		{
+			name: "wrapped status coder",
+			handler: func() error {
+				return errors.Wrap(testStatusError{status: http.StatusNotFound}, "find user")
+			},
+			wantCode: http.StatusNotFound,
+			wantBody: "find user: status 404",
+		},
+		{
+			name: "status coder in production",
+			env:  EnvTypeProd,
+			handler: func() error {
+				return errors.Wrap(testStatusError{status: http.StatusNotFound}, "find user")
+			},
+			wantCode: http.StatusNotFound,
+			wantBody: "Not Found",
+		},
+		{
+			name: "reported error",
+			handler: func(c Context) {
+				c.Error(testStatusError{status: http.StatusUnauthorized})
+			},
+			wantCode: http.StatusUnauthorized,
+			wantBody: "status 401",
+		},
+		{
+			name: "reported nil error",
+			handler: func(c Context) {
+				c.Error(nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: "ok",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.env != "" {
+				SetEnv(test.env)
+				defer SetEnv(EnvTypeDev)
+			}
+
+			f := NewWithLogger(&bytes.Buffer{})
+			f.Get("/", test.handler, func() string { return "ok" })
+
+			resp := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			assert.Nil(t, err)
+
+			f.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.wantCode, resp.Code)
+			assert.Equal(t, test.wantBody, resp.Body.String())
+		})
+	}
+}
+
+func TestFlame_ErrorHandler(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewWithLogger(&buf)
+	f.ErrorHandler(func(c Context, err error, logger *log.Logger) (int, string) {
+		logger.Error("Failed to handle request", "error", err)
+		return errorStatus(err), "oops: " + c.Request().URL.Path
+	})
+	f.Get("/returned", func() error {
+		return testStatusError{status: http.StatusConflict}
+	})
+	f.Get("/reported",
+		func(c Context) {
+			c.Error(errors.New("reported"))
+		},
+		func() { assert.Fail(t, "should not be called") },
+	)
+	f.Get("/with-status", func() (int, error) {
+		return http.StatusBadRequest, errors.New("bad request")
+	})
+
+	tests := []struct {
+		path     string
+		wantCode int
+		wantLog  string
+	}{
+		{path: "/returned", wantCode: http.StatusConflict, wantLog: "status 409"},
+		{path: "/reported", wantCode: http.StatusInternalServerError, wantLog: "reported"},
+		{path: "/with-status", wantCode: http.StatusBadRequest, wantLog: "bad request"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			buf.Reset()
+
+			resp := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, test.path, nil)
+			assert.Nil(t, err)
+
+			f.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.wantCode, resp.Code)
+			assert.Equal(t, "oops: "+test.path, resp.Body.String())
+			assert.Contains(t, buf.String(), test.wantLog)
+		})
+	}
+
+	t.Run("error handler returns error", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		f.ErrorHandler(func(err error) error {
+			return errors.Wrap(err, "handle error")
+		})
+		f.Get("/", func() error {
+			return errors.New("original")
+		})
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		assert.Equal(t, "handle error: original", resp.Body.String())
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -35,6 +36,12 @@ type Flame struct {
 	action   Handler         // The last action handler to be executed.
 	logger   *log.Logger     // The default request logger.
 
+	trustedProxies trustedProxies    // The list of trusted proxies, nil when not configured.
+	statusHandlers map[int][]Handler // The handlers for responses of specific status codes.
+	errorHandler   Handler           // The handler of errors, nil to use the default.
+	traceOptions   *TraceOptions     // The options of tracing handlers, nil when disabled.
+	validateOnRun  bool              // Whether to validate dependencies of handlers on Run.
+
 	returnHandlers *returnHandlers // The registry of route handler return handlers.
 
 	stop chan struct{} // The signal to stop the HTTP server.
@@ -57,7 +64,6 @@ func NewWithLogger(w io.Writer) *Flame {
 		stop: make(chan struct{}),
 	}
 	f.Router = newRouter(f.createContext)
-	f.NotFound(http.NotFound)
 
 	f.Map(f.logger)
 	f.Map(f.logger.StandardLog())
@@ -93,14 +99,62 @@ func (f *Flame) createContext(w http.ResponseWriter, r *http.Request, params rou
 	hs = append(hs, handlers...)
 
 	c := newContext(w, r, params, hs, urlPath)
-	c.SetParent(f)
+	// Use the underlying injector of the Flame as the parent, so that lazy providers
+	// are invoked within the request scope.
+	c.SetParent(f.Injector)
 
 	if f.action != nil {
 		c.setAction(f.action)
 	}
+	c.setTrustedProxies(f.trustedProxies)
+	c.setStatusHandlers(f.statusHandlers)
+	c.setTraceOptions(f.traceOptions)
 	return c
 }
 
+// container returns the injector of the Flame instance as an inject.Container.
+// It panics if the Injector has been replaced by an implementation that is not
+// an inject.Container.
+func (f *Flame) container() inject.Container {
+	return f.Injector.(inject.Container)
+}
+
+// MapNamed maps the value with the name to the Flame instance, see
+// inject.Container for details.
+func (f *Flame) MapNamed(name string, val interface{}) inject.Container {
+	return f.container().MapNamed(name, val)
+}
+
+// Provide maps the constructor to the Flame instance, see inject.Container for
+// details.
+func (f *Flame) Provide(constructor interface{}, lifetime ...inject.Lifetime) inject.Container {
+	return f.container().Provide(constructor, lifetime...)
+}
+
+// Decorate registers the decorator to the Flame instance, see inject.Container
+// for details.
+func (f *Flame) Decorate(decorator interface{}) inject.Container {
+	return f.container().Decorate(decorator)
+}
+
+// Unmap removes the value and the provider that are mapped to the type from the
+// Flame instance.
+func (f *Flame) Unmap(t reflect.Type) inject.Container {
+	return f.container().Unmap(t)
+}
+
+// Has returns true if the type can be resolved by the Flame instance without
+// invoking any provider.
+func (f *Flame) Has(t reflect.Type) bool {
+	return f.container().Has(t)
+}
+
+// Dispose closes values that implement io.Closer and are created by providers
+// of the Flame instance, see inject.Container for details.
+func (f *Flame) Dispose() error {
+	return f.container().Dispose()
+}
+
 // Use adds handlers of middleware to the Flame instance, and panics if any of
 // the handler is not a callable func. Middleware handlers are invoked in the
 // same order as they are added.
@@ -125,6 +179,67 @@ func (f *Flame) Action(h Handler) {
 	f.action = validateAndWrapHandler(h, nil)
 }
 
+// StatusHandler sets handlers to replace responses with the given status code
+// and an empty body, e.g. to render branded error pages, and panics if any of
+// the handler is not a callable function. Responses that are written by
+// NotFound handlers (including the default http.NotFound) and built-in handlers
+// (e.g. Recovery and the default error handler) are considered to have empty
+// bodies. Other handlers that write their own bodies are not affected.
+//
+// The status code is written by default when status handlers write the
+// response without an explicit status code, and the original response is
+// written when status handlers write nothing.
+//
+// For example:
+//
+//	f.StatusHandler(http.StatusNotFound, func() string {
+//	    return "This is a cool 404 page"
+//	})
+func (f *Flame) StatusHandler(code int, handlers ...Handler) {
+	validateAndWrapHandlers(handlers, nil)
+	if f.statusHandlers == nil {
+		f.statusHandlers = make(map[int][]Handler)
+	}
+	f.statusHandlers[code] = handlers
+}
+
+// Controller applies dependencies to fields of the controller via Apply with
+// services of the Flame instance, and returns the controller as-is for
+// registering its methods as handlers. Dependencies are applied once, thus
+// services of requests (e.g. Context) are not available to fields and should be
+// accepted by handler methods instead. It panics if the controller is not a
+// pointer to a struct or any dependency cannot be satisfied.
+//
+// NOTE: Dependencies are resolved in the scope of the Flame instance. A field
+// of the type that is provided with the Scoped lifetime (the default of
+// Flame.Provide) gets a single value for the whole lifetime of the Flame
+// instance, which differs from the values of requests and is only disposed by
+// Flame.Dispose. Fields should only depend on mapped values and providers of
+// the Singleton lifetime, and handler methods should accept scoped values.
+//
+// For example:
+//
+//	type UserHandler struct {
+//	    DB *sql.DB `inject:""`
+//	}
+//
+//	func (h *UserHandler) Get(c flamego.Context) string { ... }
+//
+//	users := flamego.Controller(f, &UserHandler{})
+//	f.Get("/users/{id}", users.Get)
+func Controller[T any](f *Flame, ctrl T) T {
+	v := reflect.ValueOf(ctrl)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("controller must be a pointer to a struct, but got %T", ctrl))
+	}
+
+	err := f.Apply(ctrl)
+	if err != nil {
+		panic(fmt.Sprintf("unable to apply dependencies to controller %T: %v", ctrl, err))
+	}
+	return ctrl
+}
+
 // BeforeHandler is a handler executes at beginning of every request. Flame
 // instance stops further process when it returns true.
 type BeforeHandler func(rw http.ResponseWriter, req *http.Request) bool
@@ -150,7 +265,9 @@ func (f *Flame) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 // Run starts the HTTP server on "0.0.0.0:2830". The listen address can be
 // altered by the environment variable "FLAMEGO_ADDR". The instance can be
-// stopped by calling `Flame.Stop`.
+// stopped by calling `Flame.Stop`. Unsatisfied dependencies of handlers that
+// are reported by `Flame.Validate` are logged as warnings before serving when
+// enabled by `Flame.ValidateOnRun`.
 func (f *Flame) Run(args ...interface{}) {
 	logger := f.logger.WithPrefix("🧙 Flamego")
 
@@ -181,6 +298,8 @@ func (f *Flame) Run(args ...interface{}) {
 		}
 	}
 
+	f.validateBeforeRun(logger)
+
 	addr := host + ":" + port
 	logger.Print("Serving on http://localhost:"+port, "env", Env())
 
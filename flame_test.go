@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -161,6 +162,325 @@ func TestFlame_NoRace(t *testing.T) {
 	}
 }
 
+func TestFlame_StatusHandler(t *testing.T) {
+	f := NewWithLogger(&bytes.Buffer{})
+	f.Use(Recovery())
+	f.StatusHandler(http.StatusNotFound, func(c Context) string {
+		return "branded 404 for " + c.Request().URL.Path
+	})
+	f.StatusHandler(http.StatusInternalServerError, func() string {
+		return "branded 500"
+	})
+	f.StatusHandler(http.StatusForbidden, func() (int, string) {
+		return http.StatusUnauthorized, "please sign in"
+	})
+	f.StatusHandler(http.StatusTeapot, func() {})
+
+	f.Get("/panic", func() { panic("here is a panic!") })
+	f.Get("/forbidden", func(c Context) {
+		c.ResponseWriter().WriteHeader(http.StatusForbidden)
+	})
+	f.Get("/own-body", func() (int, string) {
+		return http.StatusNotFound, "user not found"
+	})
+	f.Get("/error", func() error {
+		return errors.New("secret detail")
+	})
+	f.Get("/teapot", func(c Context) {
+		writeFallback(c.ResponseWriter(), func() {
+			c.ResponseWriter().Header().Set("Content-Type", "text/teapot")
+			c.ResponseWriter().WriteHeader(http.StatusTeapot)
+			_, _ = c.ResponseWriter().Write([]byte("fallback"))
+		})
+	})
+
+	tests := []struct {
+		method          string
+		path            string
+		wantCode        int
+		wantBody        string
+		wantContentType string
+	}{
+		{method: http.MethodGet, path: "/404", wantCode: http.StatusNotFound, wantBody: "branded 404 for /404"},
+		{method: http.MethodGet, path: "/panic", wantCode: http.StatusInternalServerError, wantBody: "branded 500"},
+		{method: http.MethodGet, path: "/error", wantCode: http.StatusInternalServerError, wantBody: "branded 500"},
+		{method: http.MethodGet, path: "/forbidden", wantCode: http.StatusUnauthorized, wantBody: "please sign in"},
+		{method: http.MethodGet, path: "/own-body", wantCode: http.StatusNotFound, wantBody: "user not found"},
+		{method: http.MethodGet, path: "/teapot", wantCode: http.StatusTeapot, wantBody: "fallback", wantContentType: "text/teapot"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req, err := http.NewRequest(test.method, test.path, nil)
+			assert.Nil(t, err)
+
+			f.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.wantCode, resp.Code)
+			assert.Equal(t, test.wantBody, resp.Body.String())
+			if test.wantContentType != "" {
+				assert.Equal(t, test.wantContentType, resp.Header().Get("Content-Type"))
+			}
+		})
+	}
+
+	t.Run("custom NotFound", func(t *testing.T) {
+		for name, notFound := range map[string]Handler{
+			"http.NotFound": http.NotFound,
+			"own body":      func() (int, string) { return http.StatusNotFound, "not here" },
+		} {
+			t.Run(name, func(t *testing.T) {
+				f := NewWithLogger(&bytes.Buffer{})
+				f.NotFound(notFound)
+				f.StatusHandler(http.StatusNotFound, func() string {
+					return "branded 404"
+				})
+
+				resp := httptest.NewRecorder()
+				req, err := http.NewRequest(http.MethodGet, "/404", nil)
+				assert.Nil(t, err)
+
+				f.ServeHTTP(resp, req)
+
+				assert.Equal(t, http.StatusNotFound, resp.Code)
+				assert.Equal(t, "branded 404", resp.Body.String())
+			})
+		}
+	})
+
+	t.Run("headers of fallback", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Use(func(c Context) {
+			c.ResponseWriter().Header().Set("X-Request-Id", "1")
+		})
+		f.NotFound(func(c Context) {
+			c.ResponseWriter().Header().Set("X-Request-Id", "fallback")
+			http.NotFound(c.ResponseWriter(), c.Request().Request)
+		})
+		f.StatusHandler(http.StatusNotFound, func() string {
+			return "branded 404"
+		})
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/404", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.Equal(t, "branded 404", resp.Body.String())
+		assert.Empty(t, resp.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "1", resp.Header().Get("X-Request-Id"))
+	})
+
+	t.Run("writes of global middleware", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Use(func(c Context) {
+			if c.Request().URL.Path == "/gone" {
+				c.ResponseWriter().WriteHeader(http.StatusNotFound)
+				_, _ = c.ResponseWriter().Write([]byte("gone for good"))
+			}
+		})
+		f.StatusHandler(http.StatusNotFound, func() string {
+			return "branded 404"
+		})
+
+		for path, want := range map[string]string{
+			"/404":  "branded 404",
+			"/gone": "gone for good",
+		} {
+			t.Run(path, func(t *testing.T) {
+				resp := httptest.NewRecorder()
+				req, err := http.NewRequest(http.MethodGet, path, nil)
+				assert.Nil(t, err)
+
+				f.ServeHTTP(resp, req)
+
+				assert.Equal(t, http.StatusNotFound, resp.Code)
+				assert.Equal(t, want, resp.Body.String())
+			})
+		}
+	})
+
+	t.Run("status written multiple times", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		f.StatusHandler(http.StatusForbidden, func() string {
+			return "branded 403"
+		})
+		f.Get("/", func(c Context) {
+			c.ResponseWriter().WriteHeader(http.StatusForbidden)
+			c.ResponseWriter().WriteHeader(http.StatusOK)
+		})
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+		assert.Equal(t, "branded 403", resp.Body.String())
+	})
+}
+
+func TestFlame_Provide(t *testing.T) {
+	type user struct {
+		name string
+	}
+
+	calls := 0
+	f := NewWithLogger(&bytes.Buffer{})
+	f.Provide(func(c Context) *user {
+		calls++
+		return &user{name: c.Query("name")}
+	})
+	f.Use(func(c Context) {
+		c.Next()
+	})
+	f.Get("/", func() string { return "no user needed" })
+	f.Get("/user", func(c Context, u *user) string {
+		// The same instance is reused within the request.
+		_, err := c.Invoke(func(u2 *user) { assert.Same(t, u, u2) })
+		assert.Nil(t, err)
+		return u.name
+	})
+
+	for _, name := range []string{"alice", "bob"} {
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/user?name="+name, nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+		assert.Equal(t, name, resp.Body.String())
+	}
+	assert.Equal(t, 2, calls)
+
+	resp := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	assert.Nil(t, err)
+
+	f.ServeHTTP(resp, req)
+	assert.Equal(t, "no user needed", resp.Body.String())
+	assert.Equal(t, 2, calls)
+
+	t.Run("dispose at the end of request", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		var tx *testTx
+		f.Provide(func() *testTx {
+			tx = &testTx{}
+			return tx
+		})
+		f.Get("/", func(c Context, tx *testTx) {
+			c.After(func() { assert.False(t, tx.closed) })
+		})
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+		assert.True(t, tx.closed)
+	})
+
+	t.Run("log errors of disposal", func(t *testing.T) {
+		var buf bytes.Buffer
+		f := NewWithLogger(&buf)
+		f.Provide(func() *testTx {
+			return &testTx{err: errors.New("connection reset")}
+		})
+		f.Get("/", func(*testTx) {})
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+		assert.Contains(t, buf.String(), "Failed to dispose values of the request")
+		assert.Contains(t, buf.String(), "connection reset")
+	})
+}
+
+func TestFlame_Decorate(t *testing.T) {
+	type greeting string
+
+	f := NewWithLogger(&bytes.Buffer{})
+	f.Use(func(c Context) {
+		c.Map(greeting("Hello"))
+	})
+	f.Decorate(func(g greeting) greeting { return g + ", world" })
+	f.Get("/", func(g greeting) string { return string(g) })
+	f.Get("/route",
+		func(c Context) {
+			c.Decorate(func(g greeting) greeting { return g + "!" })
+		},
+		func(g greeting) string { return string(g) },
+	)
+
+	for path, want := range map[string]string{
+		"/":      "Hello, world",
+		"/route": "Hello, world!",
+	} {
+		t.Run(path, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			assert.Nil(t, err)
+
+			f.ServeHTTP(resp, req)
+			assert.Equal(t, want, resp.Body.String())
+		})
+	}
+}
+
+type testController struct {
+	Greeting string        `inject:""`
+	Timeout  time.Duration `inject:"optional"`
+}
+
+func (h *testController) Get(c Context) string {
+	return h.Greeting + ", " + c.Param("name")
+}
+
+func TestController(t *testing.T) {
+	f := NewWithLogger(&bytes.Buffer{})
+	f.Map("Hello")
+
+	ctrl := Controller(f, &testController{})
+	assert.Equal(t, "Hello", ctrl.Greeting)
+	f.Get("/{name}", ctrl.Get)
+
+	resp := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/flamego", nil)
+	assert.Nil(t, err)
+
+	f.ServeHTTP(resp, req)
+	assert.Equal(t, "Hello, flamego", resp.Body.String())
+
+	t.Run("not a pointer to a struct", func(t *testing.T) {
+		assert.PanicsWithValue(t,
+			"controller must be a pointer to a struct, but got flamego.testController",
+			func() { Controller(f, testController{}) },
+		)
+	})
+
+	t.Run("unsatisfied dependencies", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		assert.PanicsWithValue(t,
+			`unable to apply dependencies to controller *flamego.testController: apply field "Greeting": value not found for type string`,
+			func() { Controller(f, &testController{}) },
+		)
+	})
+}
+
+type testTx struct {
+	closed bool
+	err    error // The error to be returned by Close
+}
+
+func (tx *testTx) Close() error {
+	tx.closed = true
+	return tx.err
+}
+
 func TestEnv(t *testing.T) {
 	defer SetEnv(EnvTypeDev)
 	envs := []EnvType{
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"sync"
 
 	"github.com/flamego/flamego/inject"
 )
@@ -48,9 +49,34 @@ func (invoke teapotInvoker) Invoke([]interface{}) ([]reflect.Value, error) {
 	return []reflect.Value{reflect.ValueOf(ret1), reflect.ValueOf(ret2)}, nil
 }
 
+// fastInvokers is the registry of functions that convert handlers to
+// inject.FastInvoker, keys are function types of handlers.
+var fastInvokers = struct {
+	sync.RWMutex
+	wrappers map[reflect.Type]func(Handler) Handler
+}{
+	wrappers: make(map[reflect.Type]func(Handler) Handler),
+}
+
+// RegisterFastInvoker registers the function that converts handlers of the
+// function type F to inject.FastInvoker, which is then used to wrap matching
+// handlers automatically. It panics if F is not a function type. It is usually
+// called by code that is generated by the flamego-invoker command, see
+// https://pkg.go.dev/github.com/flamego/flamego/cmd/flamego-invoker for details.
+func RegisterFastInvoker[F any](wrap func(F) inject.FastInvoker) {
+	t := reflect.TypeOf((*F)(nil)).Elem()
+	if t.Kind() != reflect.Func {
+		panic(fmt.Sprintf("fast invoker must be registered for a function type, but got %v", t))
+	}
+
+	fastInvokers.Lock()
+	defer fastInvokers.Unlock()
+	fastInvokers.wrappers[t] = func(h Handler) Handler { return wrap(h.(F)) }
+}
+
 // validateAndWrapHandler makes sure the handler is either a callable function
 // or a value that implements http.Handler, and panics otherwise. When the
-// handler is also convertible to any built-in inject.FastInvoker
+// handler is also convertible to any built-in or registered inject.FastInvoker
 // implementations, it wraps the handler automatically to gain up to 3x
 // performance improvement.
 func validateAndWrapHandler(h Handler, wrapper func(Handler) Handler) Handler {
@@ -81,6 +107,15 @@ func validateAndWrapHandler(h Handler, wrapper func(Handler) Handler) Handler {
 		panic(fmt.Sprintf("handler must be a callable function or http.Handler, but got %T", h))
 	}
 
+	fastInvokers.RLock()
+	wrap, ok := fastInvokers.wrappers[reflect.TypeOf(h)]
+	fastInvokers.RUnlock()
+	if ok {
+		return wrap(h)
+	}
+
+	// Handlers are invoked via reflection, precompute the plan at registration.
+	inject.Prepare(h)
 	if wrapper != nil {
 		h = wrapper(h)
 	}
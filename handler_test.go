@@ -73,3 +73,31 @@ func TestValidateAndWrapHandler(t *testing.T) {
 		})
 	}
 }
+
+type testRegisteredFastInvoker func(string, int) bool
+
+func (invoke testRegisteredFastInvoker) Invoke(args []interface{}) ([]reflect.Value, error) {
+	ret1 := invoke(args[0].(string), args[1].(int))
+	return []reflect.Value{reflect.ValueOf(ret1)}, nil
+}
+
+func TestRegisterFastInvoker(t *testing.T) {
+	t.Run("not a function type", func(t *testing.T) {
+		defer func() {
+			assert.Contains(t, recover(), "fast invoker must be registered for a function type")
+		}()
+		RegisterFastInvoker(func(string) inject.FastInvoker { return nil })
+	})
+
+	RegisterFastInvoker(func(h func(string, int) bool) inject.FastInvoker {
+		return testRegisteredFastInvoker(h)
+	})
+
+	h := validateAndWrapHandler(func(string, int) bool { return true }, nil)
+	_, ok := h.(testRegisteredFastInvoker)
+	assert.True(t, ok)
+
+	// Function types must be matched exactly.
+	h = validateAndWrapHandler(func(string, int) {}, nil)
+	assert.False(t, inject.IsFastInvoker(h))
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// decoratedKey is the key of a value that is created by the provider and
+// decorated as the type.
+type decoratedKey struct {
+	typ      reflect.Type
+	provider *provider
+}
+
+func (inj *injector) Decorate(decorator interface{}) Container {
+	t := reflect.TypeOf(decorator)
+	if t == nil || t.Kind() != reflect.Func || t.NumIn() != 1 || t.NumOut() != 1 || t.In(0) != t.Out(0) || t.IsVariadic() {
+		panic(fmt.Sprintf("called inject.Decorate with a value that is not a function of func(T) T: %T", decorator))
+	}
+
+	if inj.decorators == nil {
+		inj.decorators = make(map[reflect.Type][]reflect.Value)
+	}
+	inj.decorators[t.In(0)] = append(inj.decorators[t.In(0)], reflect.ValueOf(decorator))
+
+	inj.decoratedMu.Lock()
+	clear(inj.decorated)
+	inj.decoratedMu.Unlock()
+	return inj
+}
+
+// decorate returns the value of the type t wrapped by decorators of the
+// injector and its ancestors. Decorators of ancestors are applied first, thus
+// decorators of the injector wrap the outermost.
+func (inj *injector) decorate(t reflect.Type, val reflect.Value) reflect.Value {
+	if parent := inj.parentInjector(); parent != nil {
+		val = parent.decorate(t, val)
+	}
+	for _, d := range inj.decorators[t] {
+		val = d.Call([]reflect.Value{val})[0]
+	}
+	return val
+}
+
+// decorateProvided is the same as decorate but for values that are created by
+// the provider, which reuses decorated values according to the lifetime of the
+// provider. Values of the Scoped lifetime are decorated once per scope, i.e.
+// the injector, and values of the Singleton lifetime are decorated once per
+// injector that has decorators of the type. Values of the Transient lifetime
+// are decorated on every lookup.
+func (inj *injector) decorateProvided(t reflect.Type, val reflect.Value, p *provider) reflect.Value {
+	switch p.lifetime {
+	case Transient:
+		return inj.decorate(t, val)
+
+	case Singleton:
+		if parent := inj.parentInjector(); parent != nil {
+			val = parent.decorateProvided(t, val, p)
+		}
+		if len(inj.decorators[t]) == 0 {
+			return val
+		}
+		return inj.decorateOnce(decoratedKey{typ: t, provider: p}, func() reflect.Value {
+			for _, d := range inj.decorators[t] {
+				val = d.Call([]reflect.Value{val})[0]
+			}
+			return val
+		})
+
+	default:
+		if !inj.hasDecorators(t) {
+			return val
+		}
+		return inj.decorateOnce(decoratedKey{typ: t, provider: p}, func() reflect.Value {
+			return inj.decorate(t, val)
+		})
+	}
+}
+
+// decorateOnce returns the decorated value of the key in the injector, calling
+// the decorate function to create it if not exists.
+func (inj *injector) decorateOnce(key decoratedKey, decorate func() reflect.Value) reflect.Value {
+	inj.decoratedMu.Lock()
+	defer inj.decoratedMu.Unlock()
+
+	if val, ok := inj.decorated[key]; ok {
+		return val
+	}
+
+	val := decorate()
+	if inj.decorated == nil {
+		inj.decorated = make(map[decoratedKey]reflect.Value)
+	}
+	inj.decorated[key] = val
+	return val
+}
+
+// hasDecorators returns true if the injector or its ancestors have decorators
+// of the type.
+func (inj *injector) hasDecorators(t reflect.Type) bool {
+	for cur := inj; cur != nil; cur = cur.parentInjector() {
+		if len(cur.decorators[t]) > 0 {
+			return true
+		}
+	}
+	return false
+}
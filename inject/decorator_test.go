@@ -0,0 +1,170 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package inject
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjector_Decorate(t *testing.T) {
+	suffix := func(s string) func(*greeter) *greeter {
+		return func(g *greeter) *greeter {
+			return &greeter{Name: g.Name + s}
+		}
+	}
+
+	t.Run("lazy", func(t *testing.T) {
+		inj := New()
+		// Decorators are registered before the value is mapped.
+		inj.Decorate(suffix(" A")).Decorate(suffix(" B"))
+		inj.Map(&greeter{Name: "Joe"})
+
+		_, err := inj.Invoke(func(g *greeter) {
+			assert.Equal(t, "Joe A B", g.Name)
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("ancestors first", func(t *testing.T) {
+		parent := New()
+		parent.Map(&greeter{Name: "Joe"})
+		parent.Decorate(suffix(" parent"))
+
+		inj := New()
+		inj.SetParent(parent)
+		inj.Decorate(suffix(" child"))
+
+		assert.Equal(t, "Joe parent child", inj.Value(reflect.TypeOf(&greeter{})).Interface().(*greeter).Name)
+		assert.Equal(t, "Joe parent", parent.Value(reflect.TypeOf(&greeter{})).Interface().(*greeter).Name)
+	})
+
+	t.Run("interfaces", func(t *testing.T) {
+		inj := New()
+		inj.Map(&greeter{Name: "Joe"})
+		inj.Decorate(func(s fmt.Stringer) fmt.Stringer {
+			return &greeter{Name: s.(*greeter).Name + " decorated"}
+		})
+
+		_, err := inj.Invoke(func(s fmt.Stringer, g *greeter) {
+			assert.Equal(t, "Joe decorated", s.(*greeter).Name)
+			assert.Equal(t, "Joe", g.Name)
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("dependencies of providers", func(t *testing.T) {
+		inj := New()
+		inj.Map(&greeter{Name: "Joe"})
+		inj.Decorate(suffix(" decorated"))
+		inj.Provide(func(g *greeter) string { return g.Name })
+
+		_, err := inj.Invoke(func(s string) {
+			assert.Equal(t, "Joe decorated", s)
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		inj := New()
+		inj.Decorate(suffix(" decorated"))
+		assert.False(t, inj.Value(reflect.TypeOf(&greeter{})).IsValid())
+	})
+
+	t.Run("invalid decorators", func(t *testing.T) {
+		for _, decorator := range []interface{}{
+			nil,
+			"not a function",
+			func(*greeter) {},
+			func(*greeter) string { return "" },
+			func(*greeter, string) *greeter { return nil },
+		} {
+			assert.Panics(t, func() { New().Decorate(decorator) })
+		}
+	})
+}
+
+func TestInjector_DecorateLifetimes(t *testing.T) {
+	// newApp returns an injector that provides *greeter with the lifetime and
+	// decorates it, along with counters of calls to the provider and the
+	// decorator.
+	newApp := func(lifetime Lifetime) (app Container, built, wrapped *int) {
+		built, wrapped = new(int), new(int)
+		app = New()
+		app.Provide(func() *greeter {
+			*built++
+			return &greeter{Name: "Joe"}
+		}, lifetime)
+		app.Decorate(func(g *greeter) *greeter {
+			*wrapped++
+			return &greeter{Name: g.Name + " decorated"}
+		})
+		return app, built, wrapped
+	}
+	newRequest := func(app Container) Container {
+		req := New()
+		req.SetParent(app)
+		return req
+	}
+
+	t.Run("scoped", func(t *testing.T) {
+		app, built, wrapped := newApp(Scoped)
+		req := newRequest(app)
+
+		var first, second *greeter
+		_, err := req.Invoke(func(g *greeter) { first = g })
+		assert.Nil(t, err)
+		_, err = req.Invoke(func(g *greeter) { second = g })
+		assert.Nil(t, err)
+
+		assert.Equal(t, 1, *built)
+		assert.Equal(t, 1, *wrapped)
+		assert.Same(t, first, second)
+		assert.Equal(t, "Joe decorated", first.Name)
+
+		other := newRequest(app).Value(reflect.TypeOf(&greeter{})).Interface().(*greeter)
+		assert.NotSame(t, first, other)
+		assert.Equal(t, 2, *wrapped)
+	})
+
+	t.Run("singleton", func(t *testing.T) {
+		app, built, wrapped := newApp(Singleton)
+		first := newRequest(app).Value(reflect.TypeOf(&greeter{})).Interface().(*greeter)
+		second := newRequest(app).Value(reflect.TypeOf(&greeter{})).Interface().(*greeter)
+
+		assert.Equal(t, 1, *built)
+		assert.Equal(t, 1, *wrapped)
+		assert.Same(t, first, second)
+		assert.Same(t, first, app.Value(reflect.TypeOf(&greeter{})).Interface())
+	})
+
+	t.Run("transient", func(t *testing.T) {
+		app, built, wrapped := newApp(Transient)
+		req := newRequest(app)
+		first := req.Value(reflect.TypeOf(&greeter{})).Interface().(*greeter)
+		second := req.Value(reflect.TypeOf(&greeter{})).Interface().(*greeter)
+
+		assert.Equal(t, 2, *built)
+		assert.Equal(t, 2, *wrapped)
+		assert.NotSame(t, first, second)
+	})
+
+	t.Run("decorators of the scope", func(t *testing.T) {
+		app, _, _ := newApp(Singleton)
+		req := newRequest(app)
+		req.Decorate(func(g *greeter) *greeter {
+			return &greeter{Name: g.Name + " again"}
+		})
+
+		first := req.Value(reflect.TypeOf(&greeter{})).Interface().(*greeter)
+		second := req.Value(reflect.TypeOf(&greeter{})).Interface().(*greeter)
+		assert.Same(t, first, second)
+		assert.Equal(t, "Joe decorated again", first.Name)
+		assert.Equal(t, "Joe decorated", app.Value(reflect.TypeOf(&greeter{})).Interface().(*greeter).Name)
+	})
+}
@@ -7,8 +7,13 @@
 package inject
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 )
 
 // Injector represents an interface for mapping and injecting dependencies into
@@ -23,10 +28,57 @@ type Injector interface {
 	SetParent(Injector)
 }
 
+// Container is an Injector with the support of named values, providers,
+// decorators and inspection. Injectors that are created by New implement the
+// Container, and callers that only have an Injector may type-assert for it.
+// These methods are not part of the Injector for the compatibility of its
+// existing implementations.
+type Container interface {
+	Injector
+	// MapNamed maps the `interface{}` value based on its immediate type from
+	// reflect.TypeOf and the name, which allows mapping multiple values of the
+	// same type. Named values are injected to struct fields that are tagged with
+	// `inject:"name=<name>"` via Apply, and function arguments of the Named type.
+	MapNamed(name string, val interface{}) Container
+	// Provide maps the type of the first return value of the constructor to the
+	// constructor, which is invoked with dependencies for its arguments when the
+	// type is looked up, and the result is reused according to the lifetime
+	// (default is Scoped). The constructor may optionally return an error as the
+	// second return value. It panics if the constructor is not a function with one
+	// or two return values.
+	Provide(constructor interface{}, lifetime ...Lifetime) Container
+	// Decorate registers the decorator of the type T, which must be a function
+	// of func(T) T. The decorator wraps the value of T that is looked up from
+	// the injector or its descendants, regardless of where the value is mapped.
+	// Values of providers are decorated once per their lifetime, i.e. once per
+	// scope for Scoped and once for Singleton, and other values are decorated
+	// on every lookup. Decorators of ancestors are applied before ones of
+	// descendants, and decorators of the same injector are applied in the order
+	// of registration. It panics if the decorator is not a function of
+	// func(T) T.
+	Decorate(decorator interface{}) Container
+	// Unmap removes the value and the provider that are mapped to the
+	// reflect.Type in the injector. Mappings of ancestors are not affected.
+	Unmap(reflect.Type) Container
+	// Has returns true if the reflect.Type can be resolved by the injector or its
+	// ancestors, without invoking any provider.
+	Has(reflect.Type) bool
+	// Dispose closes values that implement io.Closer and are created by providers
+	// within the injector, in the reverse order of their creation. It returns the
+	// joined errors of closing values.
+	Dispose() error
+}
+
 // Applicator represents an interface for mapping dependencies to a struct.
 type Applicator interface {
 	// Apply maps dependencies in the Type map to each field in the struct that is
-	// tagged with "inject". Returns an error if the injection fails.
+	// tagged with "inject", or named dependencies to fields that are tagged with
+	// `inject:"name=<name>"`. Fields of untagged embedded structs are applied as
+	// well. Fields that are tagged with the "optional" option, e.g.
+	// `inject:"optional"` or `inject:"name=<name>,optional"`, are left unchanged
+	// when dependencies are not found. Other values of the tag are ignored, and
+	// dependencies are mapped by types. Returns the joined errors of all fields
+	// that cannot be injected.
 	Apply(interface{}) error
 }
 
@@ -77,6 +129,22 @@ type TypeMapper interface {
 type injector struct {
 	values map[reflect.Type]reflect.Value
 	parent Injector
+
+	// Maps below are allocated on demand, as most injectors (e.g. the ones of
+	// requests) do not use them.
+	named      map[namedKey]reflect.Value
+	providers  map[reflect.Type]*provider
+	scoped     map[*provider]reflect.Value // Values created by providers of the Scoped lifetime
+	decorators map[reflect.Type][]reflect.Value
+
+	decoratedMu sync.Mutex
+	decorated   map[decoratedKey]reflect.Value // Decorated values of Scoped and Singleton providers
+
+	implementorsMu sync.RWMutex
+	implementors   map[reflect.Type]reflect.Type // The cache of resolved implementors of interfaces
+
+	disposablesMu sync.Mutex
+	disposables   []io.Closer // Values created by providers that need to be closed
 }
 
 // InterfaceOf dereferences a pointer to an Interface type. It panics if value
@@ -93,8 +161,8 @@ func InterfaceOf(value interface{}) reflect.Type {
 	return t
 }
 
-// New returns a new Injector.
-func New() Injector {
+// New returns a new Container.
+func New() Container {
 	return &injector{
 		values: make(map[reflect.Type]reflect.Value),
 	}
@@ -106,49 +174,51 @@ func New() Injector {
 // Returns an error if the injection fails.
 // It panics if f is not a function
 func (inj *injector) Invoke(f interface{}) ([]reflect.Value, error) {
-	t := reflect.TypeOf(f)
+	p := planOf(reflect.TypeOf(f)) // Panic if f is not kind of Func
 	switch v := f.(type) {
 	case FastInvoker:
-		return inj.fastInvoke(v, t, t.NumIn())
+		return inj.fastInvoke(v, p)
 	default:
-		return inj.callInvoke(f, t, t.NumIn())
+		return inj.callInvoke(f, p)
 	}
 }
 
-func (inj *injector) fastInvoke(f FastInvoker, t reflect.Type, numIn int) ([]reflect.Value, error) {
+// maxBufferedArgs is the maximum number of arguments that are buffered on the
+// stack to avoid allocations when calling via reflection.
+const maxBufferedArgs = 8
+
+func (inj *injector) fastInvoke(f FastInvoker, p *plan) ([]reflect.Value, error) {
 	var in []interface{}
-	if numIn > 0 {
-		in = make([]interface{}, numIn) // Panic if t is not kind of Func
-		var argType reflect.Type
-		var val reflect.Value
-		for i := 0; i < numIn; i++ {
-			argType = t.In(i)
-			val = inj.Value(argType)
-			if !val.IsValid() {
-				return nil, fmt.Errorf("value not found for type %v", argType)
-			}
+	if len(p.in) > 0 {
+		// NOTE: Arguments escape to the heap via the interface method call anyway.
+		in = make([]interface{}, len(p.in))
+	}
 
-			in[i] = val.Interface()
+	for i, argType := range p.in {
+		val, err := inj.resolve(argType)
+		if err != nil {
+			return nil, err
 		}
+		in[i] = val.Interface()
 	}
 	return f.Invoke(in)
 }
 
-func (inj *injector) callInvoke(f interface{}, t reflect.Type, numIn int) ([]reflect.Value, error) {
+func (inj *injector) callInvoke(f interface{}, p *plan) ([]reflect.Value, error) {
+	var buf [maxBufferedArgs]reflect.Value
 	var in []reflect.Value
-	if numIn > 0 {
-		in = make([]reflect.Value, numIn)
-		var argType reflect.Type
-		var val reflect.Value
-		for i := 0; i < numIn; i++ {
-			argType = t.In(i)
-			val = inj.Value(argType)
-			if !val.IsValid() {
-				return nil, fmt.Errorf("value not found for type %v", argType)
-			}
+	if len(p.in) <= maxBufferedArgs {
+		in = buf[:len(p.in)]
+	} else {
+		in = make([]reflect.Value, len(p.in))
+	}
 
-			in[i] = val
+	for i, argType := range p.in {
+		val, err := inj.resolve(argType)
+		if err != nil {
+			return nil, err
 		}
+		in[i] = val
 	}
 	return reflect.ValueOf(f).Call(in), nil
 }
@@ -163,67 +233,299 @@ func (inj *injector) Apply(val interface{}) error {
 	if v.Kind() != reflect.Struct {
 		return nil // Should not panic here ?
 	}
+	return errors.Join(inj.applyFields(v)...)
+}
 
+// applyFields maps dependencies to tagged fields of the struct and its embedded
+// structs, and returns errors of all fields that cannot be injected.
+func (inj *injector) applyFields(v reflect.Value) []error {
+	var errs []error
 	t := v.Type()
-
 	for i := 0; i < v.NumField(); i++ {
 		f := v.Field(i)
 		structField := t.Field(i)
-		_, ok := structField.Tag.Lookup("inject")
-		if f.CanSet() && ok {
-			ft := f.Type()
-			v := inj.Value(ft)
-			if !v.IsValid() {
-				return fmt.Errorf("value not found for type %v", ft)
+		tag, ok := structField.Tag.Lookup("inject")
+		if !ok {
+			// Untagged embedded structs are applied as part of the struct.
+			if structField.Anonymous {
+				if f.Kind() == reflect.Pointer && !f.IsNil() {
+					f = f.Elem()
+				}
+				if f.Kind() == reflect.Struct {
+					errs = append(errs, inj.applyFields(f)...)
+				}
 			}
+			continue
+		}
+		if !f.CanSet() {
+			continue
+		}
 
-			f.Set(v)
+		name, optional := parseInjectTag(tag)
+		var val reflect.Value
+		var err error
+		if name == "" {
+			val, err = inj.lookup(f.Type(), resolution{scope: inj})
+		} else {
+			val, err = inj.lookupNamed(name, f.Type())
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("apply field %q: %v", structField.Name, err))
+			continue
+		} else if !val.IsValid() {
+			if !optional {
+				if name == "" {
+					err = fmt.Errorf("value not found for type %v", f.Type())
+				} else {
+					err = fmt.Errorf("value not found for type %v with name %q", f.Type(), name)
+				}
+				errs = append(errs, fmt.Errorf("apply field %q: %v", structField.Name, err))
+			}
+			continue
 		}
 
+		f.Set(val)
 	}
-	return nil
+	return errs
+}
+
+// parseInjectTag returns the name and whether the field is optional from the
+// comma-separated options of the "inject" tag, e.g.
+// `inject:"name=replica,optional"`. Unknown options are ignored to keep values
+// of the tag that are used before named dependencies are introduced mapping
+// dependencies by types.
+func parseInjectTag(tag string) (name string, optional bool) {
+	for _, opt := range strings.Split(tag, ",") {
+		opt = strings.TrimSpace(opt)
+		if opt == "optional" {
+			optional = true
+		} else if v, ok := strings.CutPrefix(opt, "name="); ok {
+			name = v
+		}
+	}
+	return name, optional
 }
 
 func (inj *injector) Map(values ...interface{}) TypeMapper {
 	for _, val := range values {
-		inj.values[reflect.TypeOf(val)] = reflect.ValueOf(val)
+		inj.Set(reflect.TypeOf(val), reflect.ValueOf(val))
 	}
 	return inj
 }
 
 func (inj *injector) MapTo(val, ifacePtr interface{}) TypeMapper {
-	inj.values[InterfaceOf(ifacePtr)] = reflect.ValueOf(val)
-	return inj
+	return inj.Set(InterfaceOf(ifacePtr), reflect.ValueOf(val))
 }
 
 func (inj *injector) Set(typ reflect.Type, val reflect.Value) TypeMapper {
 	inj.values[typ] = val
+	delete(inj.providers, typ)
+	inj.resetImplementors()
+	return inj
+}
+
+func (inj *injector) Unmap(typ reflect.Type) Container {
+	delete(inj.values, typ)
+	delete(inj.providers, typ)
+	inj.resetImplementors()
 	return inj
 }
 
+// resetImplementors clears the cache of resolved implementors of interfaces,
+// which must be called whenever the mapped types are changed.
+func (inj *injector) resetImplementors() {
+	inj.implementorsMu.Lock()
+	clear(inj.implementors)
+	inj.implementorsMu.Unlock()
+}
+
 func (inj *injector) Value(t reflect.Type) reflect.Value {
-	val := inj.values[t]
+	val, _ := inj.lookup(t, resolution{scope: inj})
+	return val
+}
+
+func (inj *injector) Has(t reflect.Type) bool {
+	var foreign Injector
+	for cur := inj; cur != nil; cur = cur.parentInjector() {
+		if _, ok := cur.values[t]; ok {
+			return true
+		}
+		if _, ok := cur.providers[t]; ok {
+			return true
+		}
+		if _, ok := cur.parent.(*injector); !ok {
+			foreign = cur.parent
+		}
+	}
+
+	if t.Implements(namedBindingType) {
+		name, typ := reflect.Zero(t).Interface().(namedBinding).binding()
+		val, err := inj.lookupNamed(name, typ)
+		return err == nil && val.IsValid()
+	}
+
+	if t.Kind() == reflect.Interface {
+		for cur := inj; cur != nil; cur = cur.parentInjector() {
+			k, err := cur.implementor(t)
+			if err != nil {
+				return false
+			} else if k != nil {
+				return true
+			}
+		}
+	}
+	if foreign == nil {
+		return false
+	} else if c, ok := foreign.(Container); ok {
+		return c.Has(t)
+	}
+	// Other implementations of the Injector can only be inspected via the Value.
+	return foreign.Value(t).IsValid()
+}
+
+// resolve returns the reflect.Value that is mapped to the reflect.Type, or an
+// error if the type has not been mapped or its provider failed.
+func (inj *injector) resolve(t reflect.Type) (reflect.Value, error) {
+	return inj.resolveIn(t, resolution{scope: inj})
+}
+
+func (inj *injector) resolveIn(t reflect.Type, r resolution) (reflect.Value, error) {
+	val, err := inj.lookup(t, r)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if !val.IsValid() {
+		return reflect.Value{}, fmt.Errorf("value not found for type %v", t)
+	}
+	return val, nil
+}
 
-	if val.IsValid() {
-		return val
+// lookup returns the reflect.Value that is mapped to the reflect.Type in the
+// injector or its ancestors. Exact bindings take precedence over implementors
+// of interfaces, and implementors in the injector take precedence over ones in
+// its ancestors. The value is then wrapped by decorators of the type.
+func (inj *injector) lookup(t reflect.Type, r resolution) (reflect.Value, error) {
+	val, p, err := inj.lookupUndecorated(t, r)
+	if err != nil || !val.IsValid() {
+		return val, err
+	}
+	if p != nil {
+		return inj.decorateProvided(t, val, p), nil
+	}
+	return inj.decorate(t, val), nil
+}
+
+// lookupUndecorated is the same as lookup but without applying decorators. It
+// also returns the provider that created the value, or nil if the value is not
+// created by a provider.
+func (inj *injector) lookupUndecorated(t reflect.Type, r resolution) (reflect.Value, *provider, error) {
+	var foreign Injector // The first ancestor that is not an injector of this package
+	for cur := inj; cur != nil; cur = cur.parentInjector() {
+		if val := cur.values[t]; val.IsValid() {
+			return val, nil, nil
+		}
+		if p, ok := cur.providers[t]; ok {
+			val, err := p.provide(cur, r)
+			return val, p, err
+		}
+		if _, ok := cur.parent.(*injector); !ok {
+			foreign = cur.parent
+		}
+	}
+
+	if t.Implements(namedBindingType) {
+		name, typ := reflect.Zero(t).Interface().(namedBinding).binding()
+		val, err := inj.resolveNamed(name, typ)
+		if err != nil {
+			return reflect.Value{}, nil, err
+		}
+		named := reflect.New(t).Elem()
+		named.Field(0).Set(val)
+		return named, nil, nil
 	}
 
 	// No concrete types found, try to find implementors if t is an interface.
 	if t.Kind() == reflect.Interface {
-		for k, v := range inj.values {
-			if k.Implements(t) {
-				val = v
-				break
+		for cur := inj; cur != nil; cur = cur.parentInjector() {
+			k, err := cur.implementor(t)
+			if err != nil {
+				return reflect.Value{}, nil, err
+			} else if k == nil {
+				continue
 			}
+
+			if val := cur.values[k]; val.IsValid() {
+				return val, nil, nil
+			}
+			p := cur.providers[k]
+			val, err := p.provide(cur, r)
+			return val, p, err
 		}
 	}
 
-	// Still no type found, try to look it up on the parent
-	if !val.IsValid() && inj.parent != nil {
-		val = inj.parent.Value(t)
+	// Still no type found, try to look it up on the foreign ancestor
+	if foreign != nil {
+		return foreign.Value(t), nil, nil
 	}
+	return reflect.Value{}, nil, nil
+}
 
-	return val
+// implementor returns the mapped type in the injector that implements the
+// interface type t, or nil if none. It returns an error if there are more than
+// one implementors.
+func (inj *injector) implementor(t reflect.Type) (reflect.Type, error) {
+	inj.implementorsMu.RLock()
+	k, ok := inj.implementors[t]
+	inj.implementorsMu.RUnlock()
+	if ok {
+		return k, nil
+	}
+
+	var candidates []reflect.Type
+	for k := range inj.values {
+		if k.Implements(t) {
+			candidates = append(candidates, k)
+		}
+	}
+	for k := range inj.providers {
+		if k.Implements(t) {
+			candidates = append(candidates, k)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		return nil, ambiguityError(t, candidates)
+	}
+
+	inj.implementorsMu.Lock()
+	if inj.implementors == nil {
+		inj.implementors = make(map[reflect.Type]reflect.Type)
+	}
+	inj.implementors[t] = candidates[0]
+	inj.implementorsMu.Unlock()
+	return candidates[0], nil
+}
+
+// ambiguityError returns an error for the interface type t that has more than
+// one implementors.
+func ambiguityError(t reflect.Type, candidates []reflect.Type) error {
+	names := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		names = append(names, c.String())
+	}
+	sort.Strings(names)
+	return fmt.Errorf("ambiguous implementors for type %v: %s", t, strings.Join(names, ", "))
+}
+
+// parentInjector returns the parent if it is an injector of this package, or
+// nil otherwise.
+func (inj *injector) parentInjector() *injector {
+	parent, _ := inj.parent.(*injector)
+	return parent
 }
 
 func (inj *injector) SetParent(parent Injector) {
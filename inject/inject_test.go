@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -91,6 +92,88 @@ func TestInjector_Apply(t *testing.T) {
 
 	assert.Equal(t, "a dep", s.Dep1)
 	assert.Equal(t, "another dep", s.Dep2)
+
+	t.Run("legacy tag values", func(t *testing.T) {
+		inj := New()
+		inj.Map("a dep")
+		inj.MapNamed("t", "a named dep")
+
+		s := struct {
+			Dep1 string `inject:"t"`
+		}{}
+		assert.Nil(t, inj.Apply(&s))
+		assert.Equal(t, "a dep", s.Dep1)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		inj := New()
+		inj.Map("a dep")
+		inj.MapNamed("primary", &greeter{"primary"})
+
+		s := struct {
+			Dep1    string        `inject:"optional"`
+			Timeout time.Duration `inject:"optional"`
+			Primary *greeter      `inject:"name=primary,optional"`
+			Replica *greeter      `inject:"name=replica,optional"`
+		}{
+			Timeout: time.Second,
+		}
+		assert.Nil(t, inj.Apply(&s))
+		assert.Equal(t, "a dep", s.Dep1)
+		assert.Equal(t, time.Second, s.Timeout)
+		assert.Equal(t, "primary", s.Primary.Name)
+		assert.Nil(t, s.Replica)
+	})
+
+	t.Run("embedded structs", func(t *testing.T) {
+		inj := New()
+		inj.Map("a dep").MapTo("another dep", (*specialString)(nil))
+
+		type embedded struct {
+			Dep2 specialString `inject:""`
+		}
+		s := struct {
+			testStruct
+			*embedded
+		}{
+			embedded: &embedded{},
+		}
+		assert.Nil(t, inj.Apply(&s))
+		assert.Equal(t, "a dep", s.Dep1)
+		assert.Equal(t, "another dep", s.testStruct.Dep2)
+		assert.Equal(t, "another dep", s.embedded.Dep2)
+	})
+
+	t.Run("all errors", func(t *testing.T) {
+		s := testStruct{}
+		err := New().Apply(&s)
+		assert.EqualError(t, err, `apply field "Dep1": value not found for type string
+apply field "Dep2": value not found for type inject.specialString`)
+	})
+}
+
+func TestParseInjectTag(t *testing.T) {
+	tests := []struct {
+		tag          string
+		wantName     string
+		wantOptional bool
+	}{
+		{tag: "", wantName: "", wantOptional: false},
+		{tag: "optional", wantName: "", wantOptional: true},
+		{tag: "name=replica", wantName: "replica", wantOptional: false},
+		{tag: "name=replica,optional", wantName: "replica", wantOptional: true},
+		{tag: "optional, name=replica", wantName: "replica", wantOptional: true},
+		{tag: ",optional", wantName: "", wantOptional: true},
+		{tag: "t", wantName: "", wantOptional: false},
+		{tag: "replica", wantName: "", wantOptional: false},
+	}
+	for _, test := range tests {
+		t.Run(test.tag, func(t *testing.T) {
+			name, optional := parseInjectTag(test.tag)
+			assert.Equal(t, test.wantName, name)
+			assert.Equal(t, test.wantOptional, optional)
+		})
+	}
 }
 
 func TestInjector_InterfaceOf(t *testing.T) {
@@ -134,6 +217,66 @@ func TestInjector_GetVal(t *testing.T) {
 	assert.False(t, inj.Value(reflect.TypeOf(11)).IsValid())
 }
 
+func TestInjector_Has(t *testing.T) {
+	parent := New()
+	parent.MapNamed("primary", &greeter{})
+	parent.Provide(func() string {
+		t.Fatal("provider should not be invoked")
+		return ""
+	})
+
+	inj := New()
+	inj.SetParent(parent)
+	inj.Map(time.Second)
+
+	assert.True(t, inj.Has(reflect.TypeOf("")))
+	assert.True(t, inj.Has(reflect.TypeOf(time.Second)))
+	assert.True(t, inj.Has(InterfaceOf((*fmt.Stringer)(nil))))
+	assert.True(t, inj.Has(reflect.TypeOf(Named[*greeter, primary]{})))
+	assert.False(t, inj.Has(reflect.TypeOf(Named[*greeter, replica]{})))
+	assert.False(t, inj.Has(reflect.TypeOf(1)))
+
+	inj.Map(&greeter{})
+	assert.False(t, inj.Has(InterfaceOf((*fmt.Stringer)(nil))), "ambiguous implementors")
+
+	t.Run("parent that is not a container", func(t *testing.T) {
+		parent := New()
+		parent.Map(1)
+
+		inj := New()
+		inj.SetParent(legacyInjector{parent})
+		assert.True(t, inj.Has(reflect.TypeOf(1)))
+		assert.False(t, inj.Has(reflect.TypeOf("")))
+	})
+}
+
+// legacyInjector is an implementation of the Injector that is not a Container.
+type legacyInjector struct {
+	Injector
+}
+
+func TestInjector_Unmap(t *testing.T) {
+	parent := New()
+	parent.Map("parent")
+
+	inj := New()
+	inj.SetParent(parent)
+	inj.Map("child")
+	inj.Provide(func() *greeter { return &greeter{} })
+
+	stringType := reflect.TypeOf("")
+	greeterType := reflect.TypeOf(&greeter{})
+	assert.Equal(t, "child", inj.Value(stringType).Interface())
+	assert.True(t, inj.Has(InterfaceOf((*fmt.Stringer)(nil))))
+
+	inj.Unmap(stringType).Unmap(greeterType)
+	assert.Equal(t, "parent", inj.Value(stringType).Interface())
+	assert.False(t, inj.Has(greeterType))
+
+	// The cache of resolved implementors is reset when mapped types changed.
+	assert.False(t, inj.Has(InterfaceOf((*fmt.Stringer)(nil))))
+}
+
 func TestInjector_SetParent(t *testing.T) {
 	inj := New()
 	inj.MapTo("another dep", (*specialString)(nil))
@@ -151,12 +294,59 @@ func TestInjector_Implementors(t *testing.T) {
 	inj.Map(g)
 
 	assert.True(t, inj.Value(InterfaceOf((*fmt.Stringer)(nil))).IsValid())
+
+	t.Run("ambiguous", func(t *testing.T) {
+		inj := New()
+		inj.Map(&greeter{"Jeremy"}, time.Second)
+
+		_, err := inj.Invoke(func(fmt.Stringer) {})
+		assert.EqualError(t, err, "ambiguous implementors for type fmt.Stringer: *inject.greeter, time.Duration")
+
+		// Exact bindings take precedence, even from the parent.
+		parent := New()
+		parent.MapTo(time.Minute, (*fmt.Stringer)(nil))
+		inj.SetParent(parent)
+		_, err = inj.Invoke(func(s fmt.Stringer) {
+			assert.Equal(t, time.Minute, s)
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("nearest implementor", func(t *testing.T) {
+		parent := New()
+		parent.Map(time.Second)
+		inj := New()
+		inj.SetParent(parent)
+		inj.Map(&greeter{"Jeremy"})
+
+		stringerType := InterfaceOf((*fmt.Stringer)(nil))
+		assert.Equal(t, "Jeremy", inj.Value(stringerType).Interface().(*greeter).Name)
+
+		// The cache of resolved implementors is reset when mapped types changed.
+		inj.Map(time.Minute)
+		_, err := inj.Invoke(func(fmt.Stringer) {})
+		assert.EqualError(t, err, "ambiguous implementors for type fmt.Stringer: *inject.greeter, time.Duration")
+	})
 }
 
 func TestIsFastInvoker(t *testing.T) {
 	assert.True(t, IsFastInvoker(myFastInvoker(nil)))
 }
 
+func TestPrepare(t *testing.T) {
+	fn := func(string, specialString) {}
+	Prepare(fn)
+
+	p, ok := plans.Load(reflect.TypeOf(fn))
+	assert.True(t, ok)
+	assert.Equal(t,
+		[]reflect.Type{reflect.TypeOf(""), InterfaceOf((*specialString)(nil))},
+		p.(*plan).in,
+	)
+
+	assert.Panics(t, func() { Prepare("not a function") })
+}
+
 func BenchmarkInjector_Invoke(b *testing.B) {
 	inj := New()
 	inj.Map("some dependency").MapTo("another dep", (*specialString)(nil))
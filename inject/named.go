@@ -0,0 +1,106 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package inject
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Qualifier is the name of a named binding in the type form, which is used as
+// the type parameter of the Named.
+type Qualifier interface {
+	Name() string
+}
+
+// Named is a dependency that resolves to the value mapped via MapNamed with the
+// name of the Q, which is useful for function arguments that cannot be tagged.
+// For example:
+//
+//	type Replica struct{}
+//
+//	func (Replica) Name() string { return "replica" }
+//
+//	inj.MapNamed("replica", replicaDB)
+//	inj.Invoke(func(db inject.Named[*sql.DB, Replica]) {
+//	    db.Value.Query(...)
+//	})
+type Named[T any, Q Qualifier] struct {
+	Value T
+}
+
+func (Named[T, Q]) binding() (name string, typ reflect.Type) {
+	var q Q
+	return q.Name(), reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// namedBinding is implemented by all instantiations of the Named.
+type namedBinding interface {
+	binding() (name string, typ reflect.Type)
+}
+
+var namedBindingType = reflect.TypeOf((*namedBinding)(nil)).Elem()
+
+// namedKey is the key of a named binding.
+type namedKey struct {
+	name string
+	typ  reflect.Type
+}
+
+func (inj *injector) MapNamed(name string, val interface{}) Container {
+	if inj.named == nil {
+		inj.named = make(map[namedKey]reflect.Value)
+	}
+	inj.named[namedKey{name: name, typ: reflect.TypeOf(val)}] = reflect.ValueOf(val)
+	return inj
+}
+
+// lookupNamed returns the reflect.Value that is mapped to the reflect.Type with
+// the name in the injector or its ancestors, with the same precedence as
+// lookup.
+func (inj *injector) lookupNamed(name string, t reflect.Type) (reflect.Value, error) {
+	for cur := inj; cur != nil; cur = cur.parentInjector() {
+		if val := cur.named[namedKey{name: name, typ: t}]; val.IsValid() {
+			return val, nil
+		}
+	}
+
+	// No concrete types found, try to find implementors if t is an interface.
+	if t.Kind() == reflect.Interface {
+		for cur := inj; cur != nil; cur = cur.parentInjector() {
+			var candidates []reflect.Type
+			for k := range cur.named {
+				if k.name == name && k.typ.Implements(t) {
+					candidates = append(candidates, k.typ)
+				}
+			}
+
+			switch len(candidates) {
+			case 0:
+				continue
+			case 1:
+				return cur.named[namedKey{name: name, typ: candidates[0]}], nil
+			default:
+				return reflect.Value{}, ambiguityError(t, candidates)
+			}
+		}
+	}
+
+	// Named bindings are only supported by injectors of this package.
+	return reflect.Value{}, nil
+}
+
+// resolveNamed returns the reflect.Value that is mapped to the reflect.Type with
+// the name, or an error if the type has not been mapped with the name.
+func (inj *injector) resolveNamed(name string, t reflect.Type) (reflect.Value, error) {
+	val, err := inj.lookupNamed(name, t)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if !val.IsValid() {
+		return reflect.Value{}, fmt.Errorf("value not found for type %v with name %q", t, name)
+	}
+	return val, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package inject
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type primary struct{}
+
+func (primary) Name() string { return "primary" }
+
+type replica struct{}
+
+func (replica) Name() string { return "replica" }
+
+func TestInjector_MapNamed(t *testing.T) {
+	parent := New()
+	parent.MapNamed("primary", &greeter{Name: "primary"})
+	parent.Map(&greeter{Name: "unnamed"})
+
+	inj := New()
+	inj.SetParent(parent)
+	inj.MapNamed("replica", &greeter{Name: "replica"})
+
+	t.Run("invoke", func(t *testing.T) {
+		_, err := inj.Invoke(func(p Named[*greeter, primary], r Named[fmt.Stringer, replica], g *greeter) {
+			assert.Equal(t, "primary", p.Value.Name)
+			assert.Equal(t, "replica", r.Value.(*greeter).Name)
+			assert.Equal(t, "unnamed", g.Name)
+		})
+		assert.Nil(t, err)
+
+		_, err = inj.Invoke(func(Named[string, primary]) {})
+		assert.EqualError(t, err, `value not found for type string with name "primary"`)
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		var s struct {
+			Primary *greeter     `inject:"name=primary"`
+			Replica fmt.Stringer `inject:"name=replica"`
+			Unnamed *greeter     `inject:""`
+		}
+		assert.Nil(t, inj.Apply(&s))
+		assert.Equal(t, "primary", s.Primary.Name)
+		assert.Equal(t, "replica", s.Replica.(*greeter).Name)
+		assert.Equal(t, "unnamed", s.Unnamed.Name)
+
+		var missing struct {
+			Replica *greeter `inject:"name=primary-replica"`
+		}
+		assert.EqualError(t, inj.Apply(&missing), `apply field "Replica": value not found for type *inject.greeter with name "primary-replica"`)
+	})
+}
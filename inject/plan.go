@@ -0,0 +1,45 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package inject
+
+import (
+	"reflect"
+	"sync"
+)
+
+// plan is the precomputed metadata to invoke functions of the same type. Plans
+// are shared by functions of the same type rather than built per function,
+// and return values are not part of plans because callers (e.g. return
+// handlers of flamego) dispatch on dynamic types of return values.
+type plan struct {
+	in []reflect.Type // The list of argument types
+}
+
+// plans is the cache of plans, keys are function types.
+var plans sync.Map
+
+// Prepare precomputes the plan to invoke functions of the same type as f, which
+// is otherwise computed on the first invocation. It is useful to move the cost
+// out of the first invocation, e.g. preparing handlers at registration. It
+// panics if f is not a function.
+func Prepare(f interface{}) {
+	planOf(reflect.TypeOf(f))
+}
+
+// planOf returns the plan of the function type, and computes it if not cached.
+func planOf(t reflect.Type) *plan {
+	if p, ok := plans.Load(t); ok {
+		return p.(*plan)
+	}
+
+	p := &plan{
+		in: make([]reflect.Type, t.NumIn()), // Panic if t is not kind of Func
+	}
+	for i := range p.in {
+		p.in[i] = t.In(i)
+	}
+	actual, _ := plans.LoadOrStore(t, p)
+	return actual.(*plan)
+}
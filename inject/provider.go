@@ -0,0 +1,179 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package inject
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// Lifetime is the lifetime of values created by a provider.
+type Lifetime int
+
+const (
+	// Scoped creates the value once per scope, i.e. the injector that the lookup
+	// originates from (e.g. a request), and reuses it within the scope.
+	Scoped Lifetime = iota
+	// Singleton creates the value once and reuses it for all lookups, including
+	// lookups from child injectors. Dependencies of the provider are resolved from
+	// the injector that the provider is registered to, and cannot be scoped.
+	Singleton
+	// Transient creates a new value for every lookup.
+	Transient
+)
+
+func (l Lifetime) String() string {
+	switch l {
+	case Scoped:
+		return "scoped"
+	case Singleton:
+		return "singleton"
+	case Transient:
+		return "transient"
+	default:
+		return fmt.Sprintf("Lifetime(%d)", int(l))
+	}
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// provider is a constructor of values with a lifetime.
+type provider struct {
+	typ         reflect.Type // The type of values created by the constructor
+	constructor reflect.Value
+	lifetime    Lifetime
+
+	mu    sync.Mutex    // Guards the value of the singleton
+	value reflect.Value // The value of the singleton
+}
+
+// resolution is the state of resolving dependencies of providers.
+type resolution struct {
+	scope     *injector      // The injector that the lookup originates from
+	path      []reflect.Type // Types whose providers are being invoked
+	singleton bool           // Whether resolving dependencies of a singleton
+}
+
+func (inj *injector) Provide(constructor interface{}, lifetime ...Lifetime) Container {
+	t := reflect.TypeOf(constructor)
+	if t == nil || t.Kind() != reflect.Func {
+		panic(fmt.Sprintf("called inject.Provide with a value that is not a function: %T", constructor))
+	}
+	if t.NumOut() == 0 || t.NumOut() > 2 || (t.NumOut() == 2 && t.Out(1) != errorType) {
+		panic(fmt.Sprintf("called inject.Provide with a function that does not return (T) or (T, error): %T", constructor))
+	}
+
+	p := &provider{
+		typ:         t.Out(0),
+		constructor: reflect.ValueOf(constructor),
+		lifetime:    Scoped,
+	}
+	if len(lifetime) > 0 {
+		p.lifetime = lifetime[0]
+	}
+	if inj.providers == nil {
+		inj.providers = make(map[reflect.Type]*provider)
+	}
+	inj.providers[p.typ] = p
+	delete(inj.values, p.typ)
+	inj.resetImplementors()
+	return inj
+}
+
+// provide returns the value of the provider that is registered to the owner,
+// creating it according to the lifetime.
+func (p *provider) provide(owner *injector, r resolution) (reflect.Value, error) {
+	for _, t := range r.path {
+		if t == p.typ {
+			return reflect.Value{}, fmt.Errorf("circular dependency for type %v", p.typ)
+		}
+	}
+
+	switch p.lifetime {
+	case Singleton:
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.value.IsValid() {
+			return p.value, nil
+		}
+
+		// Singletons outlive any scope, thus their dependencies are resolved from
+		// the owner.
+		val, err := p.invoke(owner, resolution{scope: owner, path: r.path, singleton: true})
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		p.value = val
+		return val, nil
+
+	case Transient:
+		return p.invoke(r.scope, r)
+
+	default:
+		if r.singleton {
+			return reflect.Value{}, fmt.Errorf("singleton cannot depend on scoped type %v", p.typ)
+		}
+
+		if val, ok := r.scope.scoped[p]; ok {
+			return val, nil
+		}
+
+		val, err := p.invoke(r.scope, r)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if r.scope.scoped == nil {
+			r.scope.scoped = make(map[*provider]reflect.Value)
+		}
+		r.scope.scoped[p] = val
+		return val, nil
+	}
+}
+
+// invoke calls the constructor with dependencies resolved from the scope, and
+// registers the value for disposal to the scope.
+func (p *provider) invoke(scope *injector, r resolution) (reflect.Value, error) {
+	r.path = append(r.path[:len(r.path):len(r.path)], p.typ)
+
+	ct := p.constructor.Type()
+	in := make([]reflect.Value, ct.NumIn())
+	for i := range in {
+		val, err := scope.resolveIn(ct.In(i), r)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("provide value for type %v: %v", p.typ, err)
+		}
+		in[i] = val
+	}
+
+	out := p.constructor.Call(in)
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, fmt.Errorf("provide value for type %v: %v", p.typ, out[1].Interface())
+	}
+
+	if c, ok := out[0].Interface().(io.Closer); ok {
+		scope.disposablesMu.Lock()
+		scope.disposables = append(scope.disposables, c)
+		scope.disposablesMu.Unlock()
+	}
+	return out[0], nil
+}
+
+func (inj *injector) Dispose() error {
+	inj.disposablesMu.Lock()
+	disposables := inj.disposables
+	inj.disposables = nil
+	inj.disposablesMu.Unlock()
+
+	var errs []error
+	for i := len(disposables) - 1; i >= 0; i-- {
+		if err := disposables[i].Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
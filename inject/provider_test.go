@@ -0,0 +1,181 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package inject
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjector_Provide(t *testing.T) {
+	t.Run("lazy and cached", func(t *testing.T) {
+		inj := New()
+		inj.Map("some dependency")
+
+		calls := 0
+		inj.Provide(func(dep string) *greeter {
+			calls++
+			return &greeter{Name: dep}
+		})
+		assert.Equal(t, 0, calls)
+
+		_, err := inj.Invoke(func(g1 *greeter, g2 fmt.Stringer) {
+			assert.Equal(t, "some dependency", g1.Name)
+			assert.Same(t, g1, g2)
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("cached in the scope of lookup", func(t *testing.T) {
+		parent := New()
+		parent.Provide(func(dep string) *greeter {
+			return &greeter{Name: dep}
+		})
+
+		child1 := New()
+		child1.SetParent(parent)
+		child1.Map("child1")
+		child2 := New()
+		child2.SetParent(parent)
+		child2.Map("child2")
+
+		g1 := child1.Value(reflect.TypeOf(&greeter{}))
+		g2 := child2.Value(reflect.TypeOf(&greeter{}))
+		assert.Equal(t, "child1", g1.Interface().(*greeter).Name)
+		assert.Equal(t, "child2", g2.Interface().(*greeter).Name)
+		assert.Same(t, g1.Interface(), child1.Value(reflect.TypeOf(&greeter{})).Interface())
+		assert.False(t, parent.Value(reflect.TypeOf(&greeter{})).IsValid())
+	})
+
+	t.Run("overridden by Map", func(t *testing.T) {
+		inj := New()
+		inj.Provide(func() string { return "provided" })
+		inj.Map("mapped")
+		assert.Equal(t, "mapped", inj.Value(reflect.TypeOf("")).String())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		inj := New()
+		inj.Provide(func() (*greeter, error) { return nil, fmt.Errorf("boom") })
+		_, err := inj.Invoke(func(*greeter) {})
+		assert.EqualError(t, err, "provide value for type *inject.greeter: boom")
+
+		inj = New()
+		inj.Provide(func(*greeter) string { return "" })
+		_, err = inj.Invoke(func(string) {})
+		assert.EqualError(t, err, "provide value for type string: value not found for type *inject.greeter")
+
+		inj = New()
+		inj.Provide(func(string) *greeter { return nil })
+		inj.Provide(func(*greeter) string { return "" })
+		_, err = inj.Invoke(func(string) {})
+		assert.EqualError(t, err, "provide value for type string: provide value for type *inject.greeter: circular dependency for type string")
+	})
+
+	t.Run("invalid constructors", func(t *testing.T) {
+		assert.Panics(t, func() { New().Provide("not a function") })
+		assert.Panics(t, func() { New().Provide(func() {}) })
+		assert.Panics(t, func() { New().Provide(func() (string, string) { return "", "" }) })
+	})
+}
+
+type testCloser struct {
+	name   string
+	closed *[]string
+}
+
+func (c *testCloser) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	if c.name == "bad" {
+		return fmt.Errorf("close %s", c.name)
+	}
+	return nil
+}
+
+func TestInjector_Lifetimes(t *testing.T) {
+	greeterType := reflect.TypeOf(&greeter{})
+
+	newScopes := func(lifetime Lifetime) (parent, child1, child2 Container, calls *int) {
+		calls = new(int)
+		parent = New()
+		parent.Map("parent")
+		parent.Provide(func(dep string) *greeter {
+			*calls++
+			return &greeter{Name: dep}
+		}, lifetime)
+
+		child1 = New()
+		child1.SetParent(parent)
+		child1.Map("child1")
+		child2 = New()
+		child2.SetParent(parent)
+		child2.Map("child2")
+		return parent, child1, child2, calls
+	}
+
+	t.Run("singleton", func(t *testing.T) {
+		parent, child1, child2, calls := newScopes(Singleton)
+		g1 := child1.Value(greeterType).Interface()
+		g2 := child2.Value(greeterType).Interface()
+		assert.Same(t, g1, g2)
+		assert.Same(t, g1, parent.Value(greeterType).Interface())
+		assert.Equal(t, "parent", g1.(*greeter).Name)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("scoped", func(t *testing.T) {
+		_, child1, child2, calls := newScopes(Scoped)
+		g1 := child1.Value(greeterType).Interface()
+		assert.Same(t, g1, child1.Value(greeterType).Interface())
+		g2 := child2.Value(greeterType).Interface()
+		assert.NotSame(t, g1, g2)
+		assert.Equal(t, "child1", g1.(*greeter).Name)
+		assert.Equal(t, "child2", g2.(*greeter).Name)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("transient", func(t *testing.T) {
+		_, child1, _, calls := newScopes(Transient)
+		g1 := child1.Value(greeterType).Interface()
+		g2 := child1.Value(greeterType).Interface()
+		assert.NotSame(t, g1, g2)
+		assert.Equal(t, "child1", g1.(*greeter).Name)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("singleton depends on scoped", func(t *testing.T) {
+		inj := New()
+		inj.Provide(func() string { return "scoped" })
+		inj.Provide(func(string) *greeter { return &greeter{} }, Singleton)
+		_, err := inj.Invoke(func(*greeter) {})
+		assert.EqualError(t, err, "provide value for type *inject.greeter: singleton cannot depend on scoped type string")
+	})
+}
+
+func TestInjector_Dispose(t *testing.T) {
+	var closed []string
+	parent := New()
+	parent.Provide(func() *testCloser { return &testCloser{name: "singleton", closed: &closed} }, Singleton)
+	parent.Provide(func(*testCloser) io.Closer { return &testCloser{name: "bad", closed: &closed} })
+	parent.Provide(func(io.Closer) *greeter { return &greeter{} }, Transient)
+
+	child := New()
+	child.SetParent(parent)
+	_, err := child.Invoke(func(*greeter) {})
+	assert.Nil(t, err)
+
+	// Singletons are owned by the injector that the provider is registered to.
+	assert.EqualError(t, child.Dispose(), "close bad")
+	assert.Equal(t, []string{"bad"}, closed)
+	assert.Nil(t, child.Dispose())
+
+	assert.Nil(t, parent.Dispose())
+	assert.Equal(t, []string{"bad", "singleton"}, closed)
+}
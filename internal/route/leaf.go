@@ -7,6 +7,7 @@ package route
 import (
 	"bytes"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -37,6 +38,15 @@ type Leaf interface {
 	// portion of the URL. If `withOptional` is true, the path will include the
 	// current leaf when it is optional. Otherwise, the current leaf is excluded.
 	URLPath(vals map[string]string, withOptional bool) string
+	// BuildURLPath is like URLPath but returns an error when any bind parameter
+	// is missing a value, the value does not satisfy the bind parameter (e.g.
+	// the regex), or there are values for unknown bind parameters. Values are
+	// escaped as path segments.
+	BuildURLPath(vals map[string]string, withOptional bool) (string, error)
+	// Binds returns the list of bind parameters of the route. If `withOptional`
+	// is true, bind parameters of the current leaf are included when it is
+	// optional.
+	Binds(withOptional bool) []string
 	// Route returns the string representation of the original route.
 	Route() string
 	// Handler the Handler that is associated with the leaf.
@@ -135,6 +145,138 @@ func (l *baseLeaf) URLPath(vals map[string]string, withOptional bool) string {
 	return strings.NewReplacer(pairs...).Replace(buf.String())
 }
 
+func (l *baseLeaf) BuildURLPath(vals map[string]string, withOptional bool) (string, error) {
+	used := make(map[string]struct{}, len(vals))
+	value := func(bind string) (string, error) {
+		v, ok := vals[bind]
+		if !ok || v == "" {
+			return "", errors.Errorf("missing value for bind parameter %q", bind)
+		}
+		used[bind] = struct{}{}
+		return v, nil
+	}
+	// placeholder returns the value of the bind parameter that captures a single
+	// segment.
+	placeholder := func(bind string) (string, error) {
+		v, err := value(bind)
+		if err != nil {
+			return "", err
+		}
+		if strings.Contains(v, "/") {
+			return "", errors.Errorf("value %q of bind parameter %q contains slash", v, bind)
+		}
+		return url.PathEscape(v), nil
+	}
+	// matchAll returns the value of the bind parameter that captures one or more
+	// segments up to the capture limit.
+	matchAll := func(bind string, capture int) (string, error) {
+		v, err := value(bind)
+		if err != nil {
+			return "", err
+		}
+
+		segments := strings.Split(v, "/")
+		if capture > 0 && len(segments) > capture {
+			return "", errors.Errorf("value %q of bind parameter %q exceeds the capture limit %d", v, bind, capture)
+		}
+		for i := range segments {
+			segments[i] = url.PathEscape(segments[i])
+		}
+		return strings.Join(segments, "/"), nil
+	}
+
+	var buf bytes.Buffer
+	for _, s := range l.route.Segments {
+		if s.Optional && !withOptional {
+			break
+		}
+
+		buf.WriteString("/")
+		if bind, capture, ok := checkMatchStyleAll(s); ok {
+			v, err := matchAll(bind, capture)
+			if err != nil {
+				return "", err
+			}
+			buf.WriteString(v)
+			continue
+		}
+
+		for _, e := range s.Elements {
+			if e.Ident != nil {
+				buf.WriteString(*e.Ident)
+				continue
+			} else if e.BindIdent != nil {
+				var v string
+				var err error
+				if *e.BindIdent == "**" {
+					v, err = matchAll(*e.BindIdent, 0)
+				} else {
+					v, err = placeholder(*e.BindIdent)
+				}
+				if err != nil {
+					return "", err
+				}
+				buf.WriteString(v)
+				continue
+			} else if e.BindParameters == nil {
+				return "", errors.Errorf("empty segment element in position %d", e.Pos.Offset)
+			}
+
+			for _, p := range e.BindParameters.Parameters {
+				if p.Value.Regex == nil {
+					return "", errors.Errorf("segment has non-regex literal in position %d", e.Pos.Offset)
+				}
+
+				v, err := value(p.Ident)
+				if err != nil {
+					return "", err
+				}
+
+				re, err := regexp.Compile("^(" + *p.Value.Regex + ")$")
+				if err != nil {
+					return "", errors.Wrapf(err, "compile regexp of bind parameter %q", p.Ident)
+				}
+				if !re.MatchString(v) {
+					return "", errors.Errorf("value %q of bind parameter %q does not match /%s/", v, p.Ident, *p.Value.Regex)
+				}
+				buf.WriteString(url.PathEscape(v))
+			}
+		}
+	}
+
+	for k := range vals {
+		if _, ok := used[k]; !ok {
+			return "", errors.Errorf("unknown bind parameter %q", k)
+		}
+	}
+	return buf.String(), nil
+}
+
+func (l *baseLeaf) Binds(withOptional bool) []string {
+	var binds []string
+	for _, s := range l.route.Segments {
+		if s.Optional && !withOptional {
+			break
+		}
+
+		if bind, _, ok := checkMatchStyleAll(s); ok {
+			binds = append(binds, bind)
+			continue
+		}
+
+		for _, e := range s.Elements {
+			if e.BindIdent != nil {
+				binds = append(binds, *e.BindIdent)
+			} else if e.BindParameters != nil {
+				for _, p := range e.BindParameters.Parameters {
+					binds = append(binds, p.Ident)
+				}
+			}
+		}
+	}
+	return binds
+}
+
 func (l *baseLeaf) Route() string {
 	return l.route.String()
 }
@@ -257,3 +257,195 @@ func TestLeaf_URLPath(t *testing.T) {
 		})
 	}
 }
+
+func TestLeaf_BuildURLPath(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+
+	tests := []struct {
+		route        string
+		vals         map[string]string
+		withOptional bool
+		want         string
+		wantErr      string
+	}{
+		{
+			route: "/webapi/users",
+			want:  "/webapi/users",
+		},
+		{
+			route: "/webapi/users/{name}",
+			vals: map[string]string{
+				"name": "alice bob",
+			},
+			want: "/webapi/users/alice%20bob",
+		},
+		{
+			route:   "/webapi/users/{name}",
+			wantErr: `missing value for bind parameter "name"`,
+		},
+		{
+			route: "/webapi/users/{name}",
+			vals: map[string]string{
+				"name": "alice/bob",
+			},
+			wantErr: `value "alice/bob" of bind parameter "name" contains slash`,
+		},
+		{
+			route: "/webapi/users/{name}",
+			vals: map[string]string{
+				"name": "alice",
+				"404":  "not found",
+			},
+			wantErr: `unknown bind parameter "404"`,
+		},
+		{
+			route: "/webapi/users/{name}/?{tab}",
+			vals: map[string]string{
+				"name": "alice",
+			},
+			want: "/webapi/users/alice",
+		},
+		{
+			route: "/webapi/users/{name}/?{tab}",
+			vals: map[string]string{
+				"name": "alice",
+				"tab":  "events",
+			},
+			withOptional: true,
+			want:         "/webapi/users/alice/events",
+		},
+		{
+			route: "/webapi/users/{name}/?{tab}",
+			vals: map[string]string{
+				"name": "alice",
+			},
+			withOptional: true,
+			wantErr:      `missing value for bind parameter "tab"`,
+		},
+		{
+			route: "/webapi/{paths: **}/files",
+			vals: map[string]string{
+				"paths": "src/lib",
+			},
+			want: "/webapi/src/lib/files",
+		},
+		{
+			route: "/webapi/{paths: **, capture: 2}/files",
+			vals: map[string]string{
+				"paths": "src/lib/internal",
+			},
+			wantErr: `value "src/lib/internal" of bind parameter "paths" exceeds the capture limit 2`,
+		},
+		{
+			route: "/webapi/{**}",
+			vals: map[string]string{
+				"**": "src/lib",
+			},
+			want: "/webapi/src/lib",
+		},
+		{
+			route: "/webapi/users/{id: /[0-9]+/}",
+			vals: map[string]string{
+				"id": "345",
+			},
+			want: "/webapi/users/345",
+		},
+		{
+			route: "/webapi/users/{id: /[0-9]+/}",
+			vals: map[string]string{
+				"id": "abc",
+			},
+			wantErr: `value "abc" of bind parameter "id" does not match /[0-9]+/`,
+		},
+		{
+			route: "/webapi/posts/{year: /[0-9]{4}/}-{month: /[0-9]{2}/}-{day: /[0-9]{2}/}.html",
+			vals: map[string]string{
+				"year":  "2021",
+				"month": "12",
+				"day":   "24",
+			},
+			want: "/webapi/posts/2021-12-24.html",
+		},
+		{
+			route: "/webapi/posts/{year: /[0-9]{4}/}-{month: /[0-9]{2}/}-{day: /[0-9]{2}/}.html",
+			vals: map[string]string{
+				"year": "2021",
+				"day":  "24",
+			},
+			wantErr: `missing value for bind parameter "month"`,
+		},
+		{
+			route: `/webapi/article_{id: /[0-9]+/}_{page: /[\w]+/}.{ext: /diff|patch/}`,
+			vals: map[string]string{
+				"id":   "123",
+				"page": "helloworld",
+				"ext":  "diff",
+			},
+			want: "/webapi/article_123_helloworld.diff",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.route, func(t *testing.T) {
+			route, err := parser.Parse(test.route)
+			require.NoError(t, err)
+
+			segment := route.Segments[len(route.Segments)-1]
+			leaf, err := newLeaf(nil, route, segment, nil)
+			require.NoError(t, err)
+
+			got, err := leaf.BuildURLPath(test.vals, test.withOptional)
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestLeaf_Binds(t *testing.T) {
+	parser, err := NewParser()
+	require.NoError(t, err)
+
+	tests := []struct {
+		route        string
+		withOptional bool
+		want         []string
+	}{
+		{
+			route: "/webapi/users",
+			want:  nil,
+		},
+		{
+			route: "/webapi/users/{name}/?{tab}",
+			want:  []string{"name"},
+		},
+		{
+			route:        "/webapi/users/{name}/?{tab}",
+			withOptional: true,
+			want:         []string{"name", "tab"},
+		},
+		{
+			route: "/webapi/{path: **, capture: 2}/blob",
+			want:  []string{"path"},
+		},
+		{
+			route: "/webapi/posts/{year: /[0-9]{4}/}-{month: /[0-9]{2}/}.html",
+			want:  []string{"year", "month"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.route, func(t *testing.T) {
+			route, err := parser.Parse(test.route)
+			require.NoError(t, err)
+
+			segment := route.Segments[len(route.Segments)-1]
+			leaf, err := newLeaf(nil, route, segment, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.want, leaf.Binds(test.withOptional))
+		})
+	}
+}
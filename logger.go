@@ -24,9 +24,46 @@ func (invoke LoggerInvoker) Invoke(params []interface{}) ([]reflect.Value, error
 	return nil, nil
 }
 
+// valueFields returns key-value pairs of the given keys in the per-request
+// key-value store of the context as log fields, in the order of keys. Keys
+// that are not present in the store are skipped.
+func valueFields(c Context, keys []string) []interface{} {
+	fields := make([]interface{}, 0, 2*len(keys))
+	for _, key := range keys {
+		if v, ok := c.GetValue(key); ok {
+			fields = append(fields, key, v)
+		}
+	}
+	return fields
+}
+
+// contextLogger returns the *log.Logger that is mapped to the context, or nil
+// if none.
+func contextLogger(c Context) *log.Logger {
+	if v := c.Value(reflect.TypeOf((*log.Logger)(nil))); v.IsValid() {
+		return v.Interface().(*log.Logger)
+	}
+	return nil
+}
+
+// LoggerOptions contains options for the flamego.Logger middleware.
+type LoggerOptions struct {
+	// ValueKeys is the list of keys in the per-request key-value store whose
+	// values are included as fields of the log when the response goes out. Values
+	// are not logged by default to avoid leaking sensitive data.
+	ValueKeys []string
+}
+
 // Logger returns a middleware handler that logs the request as it goes in and
-// the response as it goes out.
-func Logger() Handler {
+// the response as it goes out. Values of keys that are allow-listed by the
+// ValueKeys option in the per-request key-value store are included when the
+// response goes out.
+func Logger(opts ...LoggerOptions) Handler {
+	var opt LoggerOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
 	return LoggerInvoker(func(ctx Context, logger *log.Logger) {
 		started := time.Now()
 
@@ -40,11 +77,12 @@ func Logger() Handler {
 		w := ctx.ResponseWriter()
 		ctx.Next()
 
-		logger.Print("Completed",
+		fields := []interface{}{
 			"method", ctx.Request().Method,
 			"path", ctx.Request().RequestURI,
 			"status", w.Status(),
 			"duration", time.Since(started),
-		)
+		}
+		logger.Print("Completed", append(fields, valueFields(ctx, opt.ValueKeys)...)...)
 	})
 }
@@ -48,3 +48,43 @@ func TestLogger(t *testing.T) {
 		})
 	}
 }
+
+func TestLogger_Values(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewWithLogger(&buf)
+	f.Use(Logger(LoggerOptions{ValueKeys: []string{"user_id", "missing"}}))
+	f.Get("/", func(c Context) {
+		c.SetValue("user_id", 1)
+		c.SetValue("token", "secret")
+		c.SetValue(struct{}{}, "not a field")
+	})
+
+	resp := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	assert.Nil(t, err)
+
+	f.ServeHTTP(resp, req)
+
+	assert.Contains(t, buf.String(), "user_id=1")
+	assert.NotContains(t, buf.String(), "missing")
+	assert.NotContains(t, buf.String(), "secret")
+	assert.NotContains(t, buf.String(), "not a field")
+
+	t.Run("not allow-listed", func(t *testing.T) {
+		var buf bytes.Buffer
+		f := NewWithLogger(&buf)
+		f.Use(Logger())
+		f.Get("/", func(c Context) {
+			c.SetValue("user_id", 1)
+		})
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Contains(t, buf.String(), "Completed")
+		assert.NotContains(t, buf.String(), "user_id")
+	})
+}
@@ -3,9 +3,11 @@
 package flamego
 
 import (
+	gocontext "context"
 	"net/http"
 	"reflect"
 	"sync"
+	"time"
 
 	inject "github.com/flamego/flamego/inject"
 )
@@ -13,18 +15,69 @@ import (
 // MockContext is a mock implementation of the Context interface (from the
 // package github.com/flamego/flamego) used for unit testing.
 type MockContext struct {
+	// AbortFunc is an instance of a mock function object controlling the
+	// behavior of the method Abort.
+	AbortFunc *ContextAbortFunc
+	// AbortWithStatusFunc is an instance of a mock function object
+	// controlling the behavior of the method AbortWithStatus.
+	AbortWithStatusFunc *ContextAbortWithStatusFunc
+	// AfterFunc is an instance of a mock function object controlling the
+	// behavior of the method After.
+	AfterFunc *ContextAfterFunc
 	// ApplyFunc is an instance of a mock function object controlling the
 	// behavior of the method Apply.
 	ApplyFunc *ContextApplyFunc
+	// BaseURLFunc is an instance of a mock function object controlling the
+	// behavior of the method BaseURL.
+	BaseURLFunc *ContextBaseURLFunc
+	// ContextFunc is an instance of a mock function object controlling the
+	// behavior of the method Context.
+	ContextFunc *ContextContextFunc
 	// CookieFunc is an instance of a mock function object controlling the
 	// behavior of the method Cookie.
 	CookieFunc *ContextCookieFunc
+	// DeadlineFunc is an instance of a mock function object controlling the
+	// behavior of the method Deadline.
+	DeadlineFunc *ContextDeadlineFunc
+	// DecorateFunc is an instance of a mock function object controlling the
+	// behavior of the method Decorate.
+	DecorateFunc *ContextDecorateFunc
+	// DisposeFunc is an instance of a mock function object controlling the
+	// behavior of the method Dispose.
+	DisposeFunc *ContextDisposeFunc
+	// DoneFunc is an instance of a mock function object controlling the
+	// behavior of the method Done.
+	DoneFunc *ContextDoneFunc
+	// ErrFunc is an instance of a mock function object controlling the
+	// behavior of the method Err.
+	ErrFunc *ContextErrFunc
+	// ErrorFunc is an instance of a mock function object controlling the
+	// behavior of the method Error.
+	ErrorFunc *ContextErrorFunc
+	// GetValueFunc is an instance of a mock function object controlling the
+	// behavior of the method GetValue.
+	GetValueFunc *ContextGetValueFunc
+	// HandlerTracesFunc is an instance of a mock function object
+	// controlling the behavior of the method HandlerTraces.
+	HandlerTracesFunc *ContextHandlerTracesFunc
+	// HasFunc is an instance of a mock function object controlling the
+	// behavior of the method Has.
+	HasFunc *ContextHasFunc
+	// HostFunc is an instance of a mock function object controlling the
+	// behavior of the method Host.
+	HostFunc *ContextHostFunc
 	// InvokeFunc is an instance of a mock function object controlling the
 	// behavior of the method Invoke.
 	InvokeFunc *ContextInvokeFunc
+	// IsAbortedFunc is an instance of a mock function object controlling
+	// the behavior of the method IsAborted.
+	IsAbortedFunc *ContextIsAbortedFunc
 	// MapFunc is an instance of a mock function object controlling the
 	// behavior of the method Map.
 	MapFunc *ContextMapFunc
+	// MapNamedFunc is an instance of a mock function object controlling the
+	// behavior of the method MapNamed.
+	MapNamedFunc *ContextMapNamedFunc
 	// MapToFunc is an instance of a mock function object controlling the
 	// behavior of the method MapTo.
 	MapToFunc *ContextMapToFunc
@@ -43,6 +96,9 @@ type MockContext struct {
 	// ParamsFunc is an instance of a mock function object controlling the
 	// behavior of the method Params.
 	ParamsFunc *ContextParamsFunc
+	// ProvideFunc is an instance of a mock function object controlling the
+	// behavior of the method Provide.
+	ProvideFunc *ContextProvideFunc
 	// QueryFunc is an instance of a mock function object controlling the
 	// behavior of the method Query.
 	QueryFunc *ContextQueryFunc
@@ -79,6 +135,9 @@ type MockContext struct {
 	// ResponseWriterFunc is an instance of a mock function object
 	// controlling the behavior of the method ResponseWriter.
 	ResponseWriterFunc *ContextResponseWriterFunc
+	// SchemeFunc is an instance of a mock function object controlling the
+	// behavior of the method Scheme.
+	SchemeFunc *ContextSchemeFunc
 	// SetFunc is an instance of a mock function object controlling the
 	// behavior of the method Set.
 	SetFunc *ContextSetFunc
@@ -88,38 +147,135 @@ type MockContext struct {
 	// SetParentFunc is an instance of a mock function object controlling
 	// the behavior of the method SetParent.
 	SetParentFunc *ContextSetParentFunc
+	// SetValueFunc is an instance of a mock function object controlling the
+	// behavior of the method SetValue.
+	SetValueFunc *ContextSetValueFunc
 	// URLPathFunc is an instance of a mock function object controlling the
 	// behavior of the method URLPath.
 	URLPathFunc *ContextURLPathFunc
+	// UnmapFunc is an instance of a mock function object controlling the
+	// behavior of the method Unmap.
+	UnmapFunc *ContextUnmapFunc
 	// ValueFunc is an instance of a mock function object controlling the
 	// behavior of the method Value.
 	ValueFunc *ContextValueFunc
+	// ValuesFunc is an instance of a mock function object controlling the
+	// behavior of the method Values.
+	ValuesFunc *ContextValuesFunc
+	// WithContextFunc is an instance of a mock function object controlling
+	// the behavior of the method WithContext.
+	WithContextFunc *ContextWithContextFunc
 }
 
 // NewMockContext creates a new mock of the Context interface. All methods
 // return zero values for all results, unless overwritten.
 func NewMockContext() *MockContext {
 	return &MockContext{
+		AbortFunc: &ContextAbortFunc{
+			defaultHook: func() {
+				return
+			},
+		},
+		AbortWithStatusFunc: &ContextAbortWithStatusFunc{
+			defaultHook: func(int) {
+				return
+			},
+		},
+		AfterFunc: &ContextAfterFunc{
+			defaultHook: func(func()) {
+				return
+			},
+		},
 		ApplyFunc: &ContextApplyFunc{
 			defaultHook: func(interface{}) (r0 error) {
 				return
 			},
 		},
+		BaseURLFunc: &ContextBaseURLFunc{
+			defaultHook: func() (r0 string) {
+				return
+			},
+		},
+		ContextFunc: &ContextContextFunc{
+			defaultHook: func() (r0 gocontext.Context) {
+				return
+			},
+		},
 		CookieFunc: &ContextCookieFunc{
 			defaultHook: func(string) (r0 string) {
 				return
 			},
 		},
+		DeadlineFunc: &ContextDeadlineFunc{
+			defaultHook: func() (r0 time.Time, r1 bool) {
+				return
+			},
+		},
+		DecorateFunc: &ContextDecorateFunc{
+			defaultHook: func(interface{}) (r0 inject.Container) {
+				return
+			},
+		},
+		DisposeFunc: &ContextDisposeFunc{
+			defaultHook: func() (r0 error) {
+				return
+			},
+		},
+		DoneFunc: &ContextDoneFunc{
+			defaultHook: func() (r0 <-chan struct{}) {
+				return
+			},
+		},
+		ErrFunc: &ContextErrFunc{
+			defaultHook: func() (r0 error) {
+				return
+			},
+		},
+		ErrorFunc: &ContextErrorFunc{
+			defaultHook: func(error) {
+				return
+			},
+		},
+		GetValueFunc: &ContextGetValueFunc{
+			defaultHook: func(interface{}) (r0 interface{}, r1 bool) {
+				return
+			},
+		},
+		HandlerTracesFunc: &ContextHandlerTracesFunc{
+			defaultHook: func() (r0 []HandlerTrace) {
+				return
+			},
+		},
+		HasFunc: &ContextHasFunc{
+			defaultHook: func(reflect.Type) (r0 bool) {
+				return
+			},
+		},
+		HostFunc: &ContextHostFunc{
+			defaultHook: func() (r0 string) {
+				return
+			},
+		},
 		InvokeFunc: &ContextInvokeFunc{
 			defaultHook: func(interface{}) (r0 []reflect.Value, r1 error) {
 				return
 			},
 		},
+		IsAbortedFunc: &ContextIsAbortedFunc{
+			defaultHook: func() (r0 bool) {
+				return
+			},
+		},
 		MapFunc: &ContextMapFunc{
 			defaultHook: func(...interface{}) (r0 inject.TypeMapper) {
 				return
 			},
 		},
+		MapNamedFunc: &ContextMapNamedFunc{
+			defaultHook: func(string, interface{}) (r0 inject.Container) {
+				return
+			},
+		},
 		MapToFunc: &ContextMapToFunc{
 			defaultHook: func(interface{}, interface{}) (r0 inject.TypeMapper) {
 				return
@@ -150,6 +306,11 @@ func NewMockContext() *MockContext {
 				return
 			},
 		},
+		ProvideFunc: &ContextProvideFunc{
+			defaultHook: func(interface{}, ...inject.Lifetime) (r0 inject.Container) {
+				return
+			},
+		},
 		QueryFunc: &ContextQueryFunc{
 			defaultHook: func(string, ...string) (r0 string) {
 				return
@@ -210,6 +371,11 @@ func NewMockContext() *MockContext {
 				return
 			},
 		},
+		SchemeFunc: &ContextSchemeFunc{
+			defaultHook: func() (r0 string) {
+				return
+			},
+		},
 		SetFunc: &ContextSetFunc{
 			defaultHook: func(reflect.Type, reflect.Value) (r0 inject.TypeMapper) {
 				return
@@ -225,16 +391,36 @@ func NewMockContext() *MockContext {
 				return
 			},
 		},
+		SetValueFunc: &ContextSetValueFunc{
+			defaultHook: func(interface{}, interface{}) {
+				return
+			},
+		},
 		URLPathFunc: &ContextURLPathFunc{
 			defaultHook: func(string, ...string) (r0 string) {
 				return
 			},
 		},
+		UnmapFunc: &ContextUnmapFunc{
+			defaultHook: func(reflect.Type) (r0 inject.Container) {
+				return
+			},
+		},
 		ValueFunc: &ContextValueFunc{
 			defaultHook: func(reflect.Type) (r0 reflect.Value) {
 				return
 			},
 		},
+		ValuesFunc: &ContextValuesFunc{
+			defaultHook: func() (r0 map[interface{}]interface{}) {
+				return
+			},
+		},
+		WithContextFunc: &ContextWithContextFunc{
+			defaultHook: func(gocontext.Context) {
+				return
+			},
+		},
 	}
 }
 
@@ -242,26 +428,111 @@ func NewMockContext() *MockContext {
 // methods panic on invocation, unless overwritten.
 func NewStrictMockContext() *MockContext {
 	return &MockContext{
+		AbortFunc: &ContextAbortFunc{
+			defaultHook: func() {
+				panic("unexpected invocation of MockContext.Abort")
+			},
+		},
+		AbortWithStatusFunc: &ContextAbortWithStatusFunc{
+			defaultHook: func(int) {
+				panic("unexpected invocation of MockContext.AbortWithStatus")
+			},
+		},
+		AfterFunc: &ContextAfterFunc{
+			defaultHook: func(func()) {
+				panic("unexpected invocation of MockContext.After")
+			},
+		},
 		ApplyFunc: &ContextApplyFunc{
 			defaultHook: func(interface{}) error {
 				panic("unexpected invocation of MockContext.Apply")
 			},
 		},
+		BaseURLFunc: &ContextBaseURLFunc{
+			defaultHook: func() string {
+				panic("unexpected invocation of MockContext.BaseURL")
+			},
+		},
+		ContextFunc: &ContextContextFunc{
+			defaultHook: func() gocontext.Context {
+				panic("unexpected invocation of MockContext.Context")
+			},
+		},
 		CookieFunc: &ContextCookieFunc{
 			defaultHook: func(string) string {
 				panic("unexpected invocation of MockContext.Cookie")
 			},
 		},
+		DeadlineFunc: &ContextDeadlineFunc{
+			defaultHook: func() (time.Time, bool) {
+				panic("unexpected invocation of MockContext.Deadline")
+			},
+		},
+		DecorateFunc: &ContextDecorateFunc{
+			defaultHook: func(interface{}) inject.Container {
+				panic("unexpected invocation of MockContext.Decorate")
+			},
+		},
+		DisposeFunc: &ContextDisposeFunc{
+			defaultHook: func() error {
+				panic("unexpected invocation of MockContext.Dispose")
+			},
+		},
+		DoneFunc: &ContextDoneFunc{
+			defaultHook: func() <-chan struct{} {
+				panic("unexpected invocation of MockContext.Done")
+			},
+		},
+		ErrFunc: &ContextErrFunc{
+			defaultHook: func() error {
+				panic("unexpected invocation of MockContext.Err")
+			},
+		},
+		ErrorFunc: &ContextErrorFunc{
+			defaultHook: func(error) {
+				panic("unexpected invocation of MockContext.Error")
+			},
+		},
+		GetValueFunc: &ContextGetValueFunc{
+			defaultHook: func(interface{}) (interface{}, bool) {
+				panic("unexpected invocation of MockContext.GetValue")
+			},
+		},
+		HandlerTracesFunc: &ContextHandlerTracesFunc{
+			defaultHook: func() []HandlerTrace {
+				panic("unexpected invocation of MockContext.HandlerTraces")
+			},
+		},
+		HasFunc: &ContextHasFunc{
+			defaultHook: func(reflect.Type) bool {
+				panic("unexpected invocation of MockContext.Has")
+			},
+		},
+		HostFunc: &ContextHostFunc{
+			defaultHook: func() string {
+				panic("unexpected invocation of MockContext.Host")
+			},
+		},
 		InvokeFunc: &ContextInvokeFunc{
 			defaultHook: func(interface{}) ([]reflect.Value, error) {
 				panic("unexpected invocation of MockContext.Invoke")
 			},
 		},
+		IsAbortedFunc: &ContextIsAbortedFunc{
+			defaultHook: func() bool {
+				panic("unexpected invocation of MockContext.IsAborted")
+			},
+		},
 		MapFunc: &ContextMapFunc{
 			defaultHook: func(...interface{}) inject.TypeMapper {
 				panic("unexpected invocation of MockContext.Map")
 			},
 		},
+		MapNamedFunc: &ContextMapNamedFunc{
+			defaultHook: func(string, interface{}) inject.Container {
+				panic("unexpected invocation of MockContext.MapNamed")
+			},
+		},
 		MapToFunc: &ContextMapToFunc{
 			defaultHook: func(interface{}, interface{}) inject.TypeMapper {
 				panic("unexpected invocation of MockContext.MapTo")
@@ -292,6 +563,11 @@ func NewStrictMockContext() *MockContext {
 				panic("unexpected invocation of MockContext.Params")
 			},
 		},
+		ProvideFunc: &ContextProvideFunc{
+			defaultHook: func(interface{}, ...inject.Lifetime) inject.Container {
+				panic("unexpected invocation of MockContext.Provide")
+			},
+		},
 		QueryFunc: &ContextQueryFunc{
 			defaultHook: func(string, ...string) string {
 				panic("unexpected invocation of MockContext.Query")
@@ -352,6 +628,11 @@ func NewStrictMockContext() *MockContext {
 				panic("unexpected invocation of MockContext.ResponseWriter")
 			},
 		},
+		SchemeFunc: &ContextSchemeFunc{
+			defaultHook: func() string {
+				panic("unexpected invocation of MockContext.Scheme")
+			},
+		},
 		SetFunc: &ContextSetFunc{
 			defaultHook: func(reflect.Type, reflect.Value) inject.TypeMapper {
 				panic("unexpected invocation of MockContext.Set")
@@ -367,16 +648,36 @@ func NewStrictMockContext() *MockContext {
 				panic("unexpected invocation of MockContext.SetParent")
 			},
 		},
+		SetValueFunc: &ContextSetValueFunc{
+			defaultHook: func(interface{}, interface{}) {
+				panic("unexpected invocation of MockContext.SetValue")
+			},
+		},
 		URLPathFunc: &ContextURLPathFunc{
 			defaultHook: func(string, ...string) string {
 				panic("unexpected invocation of MockContext.URLPath")
 			},
 		},
+		UnmapFunc: &ContextUnmapFunc{
+			defaultHook: func(reflect.Type) inject.Container {
+				panic("unexpected invocation of MockContext.Unmap")
+			},
+		},
 		ValueFunc: &ContextValueFunc{
 			defaultHook: func(reflect.Type) reflect.Value {
 				panic("unexpected invocation of MockContext.Value")
 			},
 		},
+		ValuesFunc: &ContextValuesFunc{
+			defaultHook: func() map[interface{}]interface{} {
+				panic("unexpected invocation of MockContext.Values")
+			},
+		},
+		WithContextFunc: &ContextWithContextFunc{
+			defaultHook: func(gocontext.Context) {
+				panic("unexpected invocation of MockContext.WithContext")
+			},
+		},
 	}
 }
 
@@ -384,18 +685,69 @@ func NewStrictMockContext() *MockContext {
 // methods delegate to the given implementation, unless overwritten.
 func NewMockContextFrom(i Context) *MockContext {
 	return &MockContext{
+		AbortFunc: &ContextAbortFunc{
+			defaultHook: i.Abort,
+		},
+		AbortWithStatusFunc: &ContextAbortWithStatusFunc{
+			defaultHook: i.AbortWithStatus,
+		},
+		AfterFunc: &ContextAfterFunc{
+			defaultHook: i.After,
+		},
 		ApplyFunc: &ContextApplyFunc{
 			defaultHook: i.Apply,
 		},
+		BaseURLFunc: &ContextBaseURLFunc{
+			defaultHook: i.BaseURL,
+		},
+		ContextFunc: &ContextContextFunc{
+			defaultHook: i.Context,
+		},
 		CookieFunc: &ContextCookieFunc{
 			defaultHook: i.Cookie,
 		},
+		DeadlineFunc: &ContextDeadlineFunc{
+			defaultHook: i.Deadline,
+		},
+		DecorateFunc: &ContextDecorateFunc{
+			defaultHook: i.Decorate,
+		},
+		DisposeFunc: &ContextDisposeFunc{
+			defaultHook: i.Dispose,
+		},
+		DoneFunc: &ContextDoneFunc{
+			defaultHook: i.Done,
+		},
+		ErrFunc: &ContextErrFunc{
+			defaultHook: i.Err,
+		},
+		ErrorFunc: &ContextErrorFunc{
+			defaultHook: i.Error,
+		},
+		GetValueFunc: &ContextGetValueFunc{
+			defaultHook: i.GetValue,
+		},
+		HandlerTracesFunc: &ContextHandlerTracesFunc{
+			defaultHook: i.HandlerTraces,
+		},
+		HasFunc: &ContextHasFunc{
+			defaultHook: i.Has,
+		},
+		HostFunc: &ContextHostFunc{
+			defaultHook: i.Host,
+		},
 		InvokeFunc: &ContextInvokeFunc{
 			defaultHook: i.Invoke,
 		},
+		IsAbortedFunc: &ContextIsAbortedFunc{
+			defaultHook: i.IsAborted,
+		},
 		MapFunc: &ContextMapFunc{
 			defaultHook: i.Map,
 		},
+		MapNamedFunc: &ContextMapNamedFunc{
+			defaultHook: i.MapNamed,
+		},
 		MapToFunc: &ContextMapToFunc{
 			defaultHook: i.MapTo,
 		},
@@ -414,6 +766,9 @@ func NewMockContextFrom(i Context) *MockContext {
 		ParamsFunc: &ContextParamsFunc{
 			defaultHook: i.Params,
 		},
+		ProvideFunc: &ContextProvideFunc{
+			defaultHook: i.Provide,
+		},
 		QueryFunc: &ContextQueryFunc{
 			defaultHook: i.Query,
 		},
@@ -450,6 +805,9 @@ func NewMockContextFrom(i Context) *MockContext {
 		ResponseWriterFunc: &ContextResponseWriterFunc{
 			defaultHook: i.ResponseWriter,
 		},
+		SchemeFunc: &ContextSchemeFunc{
+			defaultHook: i.Scheme,
+		},
 		SetFunc: &ContextSetFunc{
 			defaultHook: i.Set,
 		},
@@ -459,43 +817,55 @@ func NewMockContextFrom(i Context) *MockContext {
 		SetParentFunc: &ContextSetParentFunc{
 			defaultHook: i.SetParent,
 		},
+		SetValueFunc: &ContextSetValueFunc{
+			defaultHook: i.SetValue,
+		},
 		URLPathFunc: &ContextURLPathFunc{
 			defaultHook: i.URLPath,
 		},
+		UnmapFunc: &ContextUnmapFunc{
+			defaultHook: i.Unmap,
+		},
 		ValueFunc: &ContextValueFunc{
 			defaultHook: i.Value,
 		},
+		ValuesFunc: &ContextValuesFunc{
+			defaultHook: i.Values,
+		},
+		WithContextFunc: &ContextWithContextFunc{
+			defaultHook: i.WithContext,
+		},
 	}
 }
 
-// ContextApplyFunc describes the behavior when the Apply method of the
+// ContextAbortFunc describes the behavior when the Abort method of the
 // parent MockContext instance is invoked.
-type ContextApplyFunc struct {
-	defaultHook func(interface{}) error
-	hooks       []func(interface{}) error
-	history     []ContextApplyFuncCall
+type ContextAbortFunc struct {
+	defaultHook func()
+	hooks       []func()
+	history     []ContextAbortFuncCall
 	mutex       sync.Mutex
 }
 
-// Apply delegates to the next hook function in the queue and stores the
+// Abort delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockContext) Apply(v0 interface{}) error {
-	r0 := m.ApplyFunc.nextHook()(v0)
-	m.ApplyFunc.appendCall(ContextApplyFuncCall{v0, r0})
-	return r0
+func (m *MockContext) Abort() {
+	m.AbortFunc.nextHook()()
+	m.AbortFunc.appendCall(ContextAbortFuncCall{})
+	return
 }
 
-// SetDefaultHook sets function that is called when the Apply method of the
+// SetDefaultHook sets function that is called when the Abort method of the
 // parent MockContext instance is invoked and the hook queue is empty.
-func (f *ContextApplyFunc) SetDefaultHook(hook func(interface{}) error) {
+func (f *ContextAbortFunc) SetDefaultHook(hook func()) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Apply method of the parent MockContext instance invokes the hook at the
+// Abort method of the parent MockContext instance invokes the hook at the
 // front of the queue and discards it. After the queue is empty, the default
 // hook function is invoked for any future action.
-func (f *ContextApplyFunc) PushHook(hook func(interface{}) error) {
+func (f *ContextAbortFunc) PushHook(hook func()) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -503,20 +873,20 @@ func (f *ContextApplyFunc) PushHook(hook func(interface{}) error) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextApplyFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(interface{}) error {
-		return r0
+func (f *ContextAbortFunc) SetDefaultReturn() {
+	f.SetDefaultHook(func() {
+		return
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextApplyFunc) PushReturn(r0 error) {
-	f.PushHook(func(interface{}) error {
-		return r0
+func (f *ContextAbortFunc) PushReturn() {
+	f.PushHook(func() {
+		return
 	})
 }
 
-func (f *ContextApplyFunc) nextHook() func(interface{}) error {
+func (f *ContextAbortFunc) nextHook() func() {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -529,74 +899,68 @@ func (f *ContextApplyFunc) nextHook() func(interface{}) error {
 	return hook
 }
 
-func (f *ContextApplyFunc) appendCall(r0 ContextApplyFuncCall) {
+func (f *ContextAbortFunc) appendCall(r0 ContextAbortFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextApplyFuncCall objects describing the
+// History returns a sequence of ContextAbortFuncCall objects describing the
 // invocations of this function.
-func (f *ContextApplyFunc) History() []ContextApplyFuncCall {
+func (f *ContextAbortFunc) History() []ContextAbortFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextApplyFuncCall, len(f.history))
+	history := make([]ContextAbortFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextApplyFuncCall is an object that describes an invocation of method
-// Apply on an instance of MockContext.
-type ContextApplyFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 interface{}
-	// Result0 is the value of the 1st result returned from this method
-	// invocation.
-	Result0 error
-}
+// ContextAbortFuncCall is an object that describes an invocation of method
+// Abort on an instance of MockContext.
+type ContextAbortFuncCall struct{}
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ContextApplyFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c ContextAbortFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextApplyFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c ContextAbortFuncCall) Results() []interface{} {
+	return []interface{}{}
 }
 
-// ContextCookieFunc describes the behavior when the Cookie method of the
-// parent MockContext instance is invoked.
-type ContextCookieFunc struct {
-	defaultHook func(string) string
-	hooks       []func(string) string
-	history     []ContextCookieFuncCall
+// ContextAbortWithStatusFunc describes the behavior when the
+// AbortWithStatus method of the parent MockContext instance is invoked.
+type ContextAbortWithStatusFunc struct {
+	defaultHook func(int)
+	hooks       []func(int)
+	history     []ContextAbortWithStatusFuncCall
 	mutex       sync.Mutex
 }
 
-// Cookie delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockContext) Cookie(v0 string) string {
-	r0 := m.CookieFunc.nextHook()(v0)
-	m.CookieFunc.appendCall(ContextCookieFuncCall{v0, r0})
-	return r0
+// AbortWithStatus delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockContext) AbortWithStatus(v0 int) {
+	m.AbortWithStatusFunc.nextHook()(v0)
+	m.AbortWithStatusFunc.appendCall(ContextAbortWithStatusFuncCall{v0})
+	return
 }
 
-// SetDefaultHook sets function that is called when the Cookie method of the
-// parent MockContext instance is invoked and the hook queue is empty.
-func (f *ContextCookieFunc) SetDefaultHook(hook func(string) string) {
+// SetDefaultHook sets function that is called when the AbortWithStatus
+// method of the parent MockContext instance is invoked and the hook queue
+// is empty.
+func (f *ContextAbortWithStatusFunc) SetDefaultHook(hook func(int)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Cookie method of the parent MockContext instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *ContextCookieFunc) PushHook(hook func(string) string) {
+// AbortWithStatus method of the parent MockContext instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ContextAbortWithStatusFunc) PushHook(hook func(int)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -604,20 +968,20 @@ func (f *ContextCookieFunc) PushHook(hook func(string) string) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextCookieFunc) SetDefaultReturn(r0 string) {
-	f.SetDefaultHook(func(string) string {
-		return r0
+func (f *ContextAbortWithStatusFunc) SetDefaultReturn() {
+	f.SetDefaultHook(func(int) {
+		return
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextCookieFunc) PushReturn(r0 string) {
-	f.PushHook(func(string) string {
-		return r0
+func (f *ContextAbortWithStatusFunc) PushReturn() {
+	f.PushHook(func(int) {
+		return
 	})
 }
 
-func (f *ContextCookieFunc) nextHook() func(string) string {
+func (f *ContextAbortWithStatusFunc) nextHook() func(int) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -630,74 +994,71 @@ func (f *ContextCookieFunc) nextHook() func(string) string {
 	return hook
 }
 
-func (f *ContextCookieFunc) appendCall(r0 ContextCookieFuncCall) {
+func (f *ContextAbortWithStatusFunc) appendCall(r0 ContextAbortWithStatusFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextCookieFuncCall objects describing
-// the invocations of this function.
-func (f *ContextCookieFunc) History() []ContextCookieFuncCall {
+// History returns a sequence of ContextAbortWithStatusFuncCall objects
+// describing the invocations of this function.
+func (f *ContextAbortWithStatusFunc) History() []ContextAbortWithStatusFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextCookieFuncCall, len(f.history))
+	history := make([]ContextAbortWithStatusFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextCookieFuncCall is an object that describes an invocation of method
-// Cookie on an instance of MockContext.
-type ContextCookieFuncCall struct {
+// ContextAbortWithStatusFuncCall is an object that describes an invocation
+// of method AbortWithStatus on an instance of MockContext.
+type ContextAbortWithStatusFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 string
-	// Result0 is the value of the 1st result returned from this method
-	// invocation.
-	Result0 string
+	Arg0 int
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ContextCookieFuncCall) Args() []interface{} {
+func (c ContextAbortWithStatusFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextCookieFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c ContextAbortWithStatusFuncCall) Results() []interface{} {
+	return []interface{}{}
 }
 
-// ContextInvokeFunc describes the behavior when the Invoke method of the
+// ContextAfterFunc describes the behavior when the After method of the
 // parent MockContext instance is invoked.
-type ContextInvokeFunc struct {
-	defaultHook func(interface{}) ([]reflect.Value, error)
-	hooks       []func(interface{}) ([]reflect.Value, error)
-	history     []ContextInvokeFuncCall
+type ContextAfterFunc struct {
+	defaultHook func(func())
+	hooks       []func(func())
+	history     []ContextAfterFuncCall
 	mutex       sync.Mutex
 }
 
-// Invoke delegates to the next hook function in the queue and stores the
+// After delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockContext) Invoke(v0 interface{}) ([]reflect.Value, error) {
-	r0, r1 := m.InvokeFunc.nextHook()(v0)
-	m.InvokeFunc.appendCall(ContextInvokeFuncCall{v0, r0, r1})
-	return r0, r1
+func (m *MockContext) After(v0 func()) {
+	m.AfterFunc.nextHook()(v0)
+	m.AfterFunc.appendCall(ContextAfterFuncCall{v0})
+	return
 }
 
-// SetDefaultHook sets function that is called when the Invoke method of the
+// SetDefaultHook sets function that is called when the After method of the
 // parent MockContext instance is invoked and the hook queue is empty.
-func (f *ContextInvokeFunc) SetDefaultHook(hook func(interface{}) ([]reflect.Value, error)) {
+func (f *ContextAfterFunc) SetDefaultHook(hook func(func())) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Invoke method of the parent MockContext instance invokes the hook at the
+// After method of the parent MockContext instance invokes the hook at the
 // front of the queue and discards it. After the queue is empty, the default
 // hook function is invoked for any future action.
-func (f *ContextInvokeFunc) PushHook(hook func(interface{}) ([]reflect.Value, error)) {
+func (f *ContextAfterFunc) PushHook(hook func(func())) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -705,20 +1066,20 @@ func (f *ContextInvokeFunc) PushHook(hook func(interface{}) ([]reflect.Value, er
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextInvokeFunc) SetDefaultReturn(r0 []reflect.Value, r1 error) {
-	f.SetDefaultHook(func(interface{}) ([]reflect.Value, error) {
-		return r0, r1
+func (f *ContextAfterFunc) SetDefaultReturn() {
+	f.SetDefaultHook(func(func()) {
+		return
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextInvokeFunc) PushReturn(r0 []reflect.Value, r1 error) {
-	f.PushHook(func(interface{}) ([]reflect.Value, error) {
-		return r0, r1
+func (f *ContextAfterFunc) PushReturn() {
+	f.PushHook(func(func()) {
+		return
 	})
 }
 
-func (f *ContextInvokeFunc) nextHook() func(interface{}) ([]reflect.Value, error) {
+func (f *ContextAfterFunc) nextHook() func(func()) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -731,77 +1092,71 @@ func (f *ContextInvokeFunc) nextHook() func(interface{}) ([]reflect.Value, error
 	return hook
 }
 
-func (f *ContextInvokeFunc) appendCall(r0 ContextInvokeFuncCall) {
+func (f *ContextAfterFunc) appendCall(r0 ContextAfterFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextInvokeFuncCall objects describing
-// the invocations of this function.
-func (f *ContextInvokeFunc) History() []ContextInvokeFuncCall {
+// History returns a sequence of ContextAfterFuncCall objects describing the
+// invocations of this function.
+func (f *ContextAfterFunc) History() []ContextAfterFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextInvokeFuncCall, len(f.history))
+	history := make([]ContextAfterFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextInvokeFuncCall is an object that describes an invocation of method
-// Invoke on an instance of MockContext.
-type ContextInvokeFuncCall struct {
+// ContextAfterFuncCall is an object that describes an invocation of method
+// After on an instance of MockContext.
+type ContextAfterFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 interface{}
-	// Result0 is the value of the 1st result returned from this method
-	// invocation.
-	Result0 []reflect.Value
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Arg0 func()
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ContextInvokeFuncCall) Args() []interface{} {
+func (c ContextAfterFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextInvokeFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c ContextAfterFuncCall) Results() []interface{} {
+	return []interface{}{}
 }
 
-// ContextMapFunc describes the behavior when the Map method of the parent
-// MockContext instance is invoked.
-type ContextMapFunc struct {
-	defaultHook func(...interface{}) inject.TypeMapper
-	hooks       []func(...interface{}) inject.TypeMapper
-	history     []ContextMapFuncCall
+// ContextApplyFunc describes the behavior when the Apply method of the
+// parent MockContext instance is invoked.
+type ContextApplyFunc struct {
+	defaultHook func(interface{}) error
+	hooks       []func(interface{}) error
+	history     []ContextApplyFuncCall
 	mutex       sync.Mutex
 }
 
-// Map delegates to the next hook function in the queue and stores the
+// Apply delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockContext) Map(v0 ...interface{}) inject.TypeMapper {
-	r0 := m.MapFunc.nextHook()(v0...)
-	m.MapFunc.appendCall(ContextMapFuncCall{v0, r0})
+func (m *MockContext) Apply(v0 interface{}) error {
+	r0 := m.ApplyFunc.nextHook()(v0)
+	m.ApplyFunc.appendCall(ContextApplyFuncCall{v0, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Map method of the
+// SetDefaultHook sets function that is called when the Apply method of the
 // parent MockContext instance is invoked and the hook queue is empty.
-func (f *ContextMapFunc) SetDefaultHook(hook func(...interface{}) inject.TypeMapper) {
+func (f *ContextApplyFunc) SetDefaultHook(hook func(interface{}) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Map method of the parent MockContext instance invokes the hook at the
+// Apply method of the parent MockContext instance invokes the hook at the
 // front of the queue and discards it. After the queue is empty, the default
 // hook function is invoked for any future action.
-func (f *ContextMapFunc) PushHook(hook func(...interface{}) inject.TypeMapper) {
+func (f *ContextApplyFunc) PushHook(hook func(interface{}) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -809,20 +1164,20 @@ func (f *ContextMapFunc) PushHook(hook func(...interface{}) inject.TypeMapper) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextMapFunc) SetDefaultReturn(r0 inject.TypeMapper) {
-	f.SetDefaultHook(func(...interface{}) inject.TypeMapper {
+func (f *ContextApplyFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(interface{}) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextMapFunc) PushReturn(r0 inject.TypeMapper) {
-	f.PushHook(func(...interface{}) inject.TypeMapper {
+func (f *ContextApplyFunc) PushReturn(r0 error) {
+	f.PushHook(func(interface{}) error {
 		return r0
 	})
 }
 
-func (f *ContextMapFunc) nextHook() func(...interface{}) inject.TypeMapper {
+func (f *ContextApplyFunc) nextHook() func(interface{}) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -835,81 +1190,74 @@ func (f *ContextMapFunc) nextHook() func(...interface{}) inject.TypeMapper {
 	return hook
 }
 
-func (f *ContextMapFunc) appendCall(r0 ContextMapFuncCall) {
+func (f *ContextApplyFunc) appendCall(r0 ContextApplyFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextMapFuncCall objects describing the
+// History returns a sequence of ContextApplyFuncCall objects describing the
 // invocations of this function.
-func (f *ContextMapFunc) History() []ContextMapFuncCall {
+func (f *ContextApplyFunc) History() []ContextApplyFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextMapFuncCall, len(f.history))
+	history := make([]ContextApplyFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextMapFuncCall is an object that describes an invocation of method
-// Map on an instance of MockContext.
-type ContextMapFuncCall struct {
-	// Arg0 is a slice containing the values of the variadic arguments
-	// passed to this method invocation.
-	Arg0 []interface{}
+// ContextApplyFuncCall is an object that describes an invocation of method
+// Apply on an instance of MockContext.
+type ContextApplyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 interface{}
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 inject.TypeMapper
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation. The variadic slice argument is flattened in this array such
-// that one positional argument and three variadic arguments would result in
-// a slice of four, not two.
-func (c ContextMapFuncCall) Args() []interface{} {
-	trailing := []interface{}{}
-	for _, val := range c.Arg0 {
-		trailing = append(trailing, val)
-	}
-
-	return append([]interface{}{}, trailing...)
+// invocation.
+func (c ContextApplyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextMapFuncCall) Results() []interface{} {
+func (c ContextApplyFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// ContextMapToFunc describes the behavior when the MapTo method of the
+// ContextBaseURLFunc describes the behavior when the BaseURL method of the
 // parent MockContext instance is invoked.
-type ContextMapToFunc struct {
-	defaultHook func(interface{}, interface{}) inject.TypeMapper
-	hooks       []func(interface{}, interface{}) inject.TypeMapper
-	history     []ContextMapToFuncCall
+type ContextBaseURLFunc struct {
+	defaultHook func() string
+	hooks       []func() string
+	history     []ContextBaseURLFuncCall
 	mutex       sync.Mutex
 }
 
-// MapTo delegates to the next hook function in the queue and stores the
+// BaseURL delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockContext) MapTo(v0 interface{}, v1 interface{}) inject.TypeMapper {
-	r0 := m.MapToFunc.nextHook()(v0, v1)
-	m.MapToFunc.appendCall(ContextMapToFuncCall{v0, v1, r0})
+func (m *MockContext) BaseURL() string {
+	r0 := m.BaseURLFunc.nextHook()()
+	m.BaseURLFunc.appendCall(ContextBaseURLFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the MapTo method of the
-// parent MockContext instance is invoked and the hook queue is empty.
-func (f *ContextMapToFunc) SetDefaultHook(hook func(interface{}, interface{}) inject.TypeMapper) {
+// SetDefaultHook sets function that is called when the BaseURL method of
+// the parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextBaseURLFunc) SetDefaultHook(hook func() string) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// MapTo method of the parent MockContext instance invokes the hook at the
+// BaseURL method of the parent MockContext instance invokes the hook at the
 // front of the queue and discards it. After the queue is empty, the default
 // hook function is invoked for any future action.
-func (f *ContextMapToFunc) PushHook(hook func(interface{}, interface{}) inject.TypeMapper) {
+func (f *ContextBaseURLFunc) PushHook(hook func() string) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -917,20 +1265,20 @@ func (f *ContextMapToFunc) PushHook(hook func(interface{}, interface{}) inject.T
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextMapToFunc) SetDefaultReturn(r0 inject.TypeMapper) {
-	f.SetDefaultHook(func(interface{}, interface{}) inject.TypeMapper {
+func (f *ContextBaseURLFunc) SetDefaultReturn(r0 string) {
+	f.SetDefaultHook(func() string {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextMapToFunc) PushReturn(r0 inject.TypeMapper) {
-	f.PushHook(func(interface{}, interface{}) inject.TypeMapper {
+func (f *ContextBaseURLFunc) PushReturn(r0 string) {
+	f.PushHook(func() string {
 		return r0
 	})
 }
 
-func (f *ContextMapToFunc) nextHook() func(interface{}, interface{}) inject.TypeMapper {
+func (f *ContextBaseURLFunc) nextHook() func() string {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -943,77 +1291,71 @@ func (f *ContextMapToFunc) nextHook() func(interface{}, interface{}) inject.Type
 	return hook
 }
 
-func (f *ContextMapToFunc) appendCall(r0 ContextMapToFuncCall) {
+func (f *ContextBaseURLFunc) appendCall(r0 ContextBaseURLFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextMapToFuncCall objects describing the
-// invocations of this function.
-func (f *ContextMapToFunc) History() []ContextMapToFuncCall {
+// History returns a sequence of ContextBaseURLFuncCall objects describing
+// the invocations of this function.
+func (f *ContextBaseURLFunc) History() []ContextBaseURLFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextMapToFuncCall, len(f.history))
+	history := make([]ContextBaseURLFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextMapToFuncCall is an object that describes an invocation of method
-// MapTo on an instance of MockContext.
-type ContextMapToFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 interface{}
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 interface{}
+// ContextBaseURLFuncCall is an object that describes an invocation of
+// method BaseURL on an instance of MockContext.
+type ContextBaseURLFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 inject.TypeMapper
+	Result0 string
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ContextMapToFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c ContextBaseURLFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextMapToFuncCall) Results() []interface{} {
+func (c ContextBaseURLFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// ContextNextFunc describes the behavior when the Next method of the parent
-// MockContext instance is invoked.
-type ContextNextFunc struct {
-	defaultHook func()
-	hooks       []func()
-	history     []ContextNextFuncCall
+// ContextContextFunc describes the behavior when the Context method of the
+// parent MockContext instance is invoked.
+type ContextContextFunc struct {
+	defaultHook func() gocontext.Context
+	hooks       []func() gocontext.Context
+	history     []ContextContextFuncCall
 	mutex       sync.Mutex
 }
 
-// Next delegates to the next hook function in the queue and stores the
+// Context delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockContext) Next() {
-	m.NextFunc.nextHook()()
-	m.NextFunc.appendCall(ContextNextFuncCall{})
-	return
+func (m *MockContext) Context() gocontext.Context {
+	r0 := m.ContextFunc.nextHook()()
+	m.ContextFunc.appendCall(ContextContextFuncCall{r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Next method of the
-// parent MockContext instance is invoked and the hook queue is empty.
-func (f *ContextNextFunc) SetDefaultHook(hook func()) {
+// SetDefaultHook sets function that is called when the Context method of
+// the parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextContextFunc) SetDefaultHook(hook func() gocontext.Context) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Next method of the parent MockContext instance invokes the hook at the
+// Context method of the parent MockContext instance invokes the hook at the
 // front of the queue and discards it. After the queue is empty, the default
 // hook function is invoked for any future action.
-func (f *ContextNextFunc) PushHook(hook func()) {
+func (f *ContextContextFunc) PushHook(hook func() gocontext.Context) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1021,20 +1363,20 @@ func (f *ContextNextFunc) PushHook(hook func()) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextNextFunc) SetDefaultReturn() {
-	f.SetDefaultHook(func() {
-		return
+func (f *ContextContextFunc) SetDefaultReturn(r0 gocontext.Context) {
+	f.SetDefaultHook(func() gocontext.Context {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextNextFunc) PushReturn() {
-	f.PushHook(func() {
-		return
+func (f *ContextContextFunc) PushReturn(r0 gocontext.Context) {
+	f.PushHook(func() gocontext.Context {
+		return r0
 	})
 }
 
-func (f *ContextNextFunc) nextHook() func() {
+func (f *ContextContextFunc) nextHook() func() gocontext.Context {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1047,67 +1389,71 @@ func (f *ContextNextFunc) nextHook() func() {
 	return hook
 }
 
-func (f *ContextNextFunc) appendCall(r0 ContextNextFuncCall) {
+func (f *ContextContextFunc) appendCall(r0 ContextContextFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextNextFuncCall objects describing the
-// invocations of this function.
-func (f *ContextNextFunc) History() []ContextNextFuncCall {
+// History returns a sequence of ContextContextFuncCall objects describing
+// the invocations of this function.
+func (f *ContextContextFunc) History() []ContextContextFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextNextFuncCall, len(f.history))
+	history := make([]ContextContextFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextNextFuncCall is an object that describes an invocation of method
-// Next on an instance of MockContext.
-type ContextNextFuncCall struct{}
+// ContextContextFuncCall is an object that describes an invocation of
+// method Context on an instance of MockContext.
+type ContextContextFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 gocontext.Context
+}
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ContextNextFuncCall) Args() []interface{} {
+func (c ContextContextFuncCall) Args() []interface{} {
 	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextNextFuncCall) Results() []interface{} {
-	return []interface{}{}
+func (c ContextContextFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// ContextParamFunc describes the behavior when the Param method of the
+// ContextCookieFunc describes the behavior when the Cookie method of the
 // parent MockContext instance is invoked.
-type ContextParamFunc struct {
+type ContextCookieFunc struct {
 	defaultHook func(string) string
 	hooks       []func(string) string
-	history     []ContextParamFuncCall
+	history     []ContextCookieFuncCall
 	mutex       sync.Mutex
 }
 
-// Param delegates to the next hook function in the queue and stores the
+// Cookie delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockContext) Param(v0 string) string {
-	r0 := m.ParamFunc.nextHook()(v0)
-	m.ParamFunc.appendCall(ContextParamFuncCall{v0, r0})
+func (m *MockContext) Cookie(v0 string) string {
+	r0 := m.CookieFunc.nextHook()(v0)
+	m.CookieFunc.appendCall(ContextCookieFuncCall{v0, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Param method of the
+// SetDefaultHook sets function that is called when the Cookie method of the
 // parent MockContext instance is invoked and the hook queue is empty.
-func (f *ContextParamFunc) SetDefaultHook(hook func(string) string) {
+func (f *ContextCookieFunc) SetDefaultHook(hook func(string) string) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Param method of the parent MockContext instance invokes the hook at the
+// Cookie method of the parent MockContext instance invokes the hook at the
 // front of the queue and discards it. After the queue is empty, the default
 // hook function is invoked for any future action.
-func (f *ContextParamFunc) PushHook(hook func(string) string) {
+func (f *ContextCookieFunc) PushHook(hook func(string) string) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1115,20 +1461,20 @@ func (f *ContextParamFunc) PushHook(hook func(string) string) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextParamFunc) SetDefaultReturn(r0 string) {
+func (f *ContextCookieFunc) SetDefaultReturn(r0 string) {
 	f.SetDefaultHook(func(string) string {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextParamFunc) PushReturn(r0 string) {
+func (f *ContextCookieFunc) PushReturn(r0 string) {
 	f.PushHook(func(string) string {
 		return r0
 	})
 }
 
-func (f *ContextParamFunc) nextHook() func(string) string {
+func (f *ContextCookieFunc) nextHook() func(string) string {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1141,26 +1487,26 @@ func (f *ContextParamFunc) nextHook() func(string) string {
 	return hook
 }
 
-func (f *ContextParamFunc) appendCall(r0 ContextParamFuncCall) {
+func (f *ContextCookieFunc) appendCall(r0 ContextCookieFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextParamFuncCall objects describing the
-// invocations of this function.
-func (f *ContextParamFunc) History() []ContextParamFuncCall {
+// History returns a sequence of ContextCookieFuncCall objects describing
+// the invocations of this function.
+func (f *ContextCookieFunc) History() []ContextCookieFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextParamFuncCall, len(f.history))
+	history := make([]ContextCookieFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextParamFuncCall is an object that describes an invocation of method
-// Param on an instance of MockContext.
-type ContextParamFuncCall struct {
+// ContextCookieFuncCall is an object that describes an invocation of method
+// Cookie on an instance of MockContext.
+type ContextCookieFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 string
@@ -1171,44 +1517,44 @@ type ContextParamFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ContextParamFuncCall) Args() []interface{} {
+func (c ContextCookieFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextParamFuncCall) Results() []interface{} {
+func (c ContextCookieFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// ContextParamIntFunc describes the behavior when the ParamInt method of
+// ContextDeadlineFunc describes the behavior when the Deadline method of
 // the parent MockContext instance is invoked.
-type ContextParamIntFunc struct {
-	defaultHook func(string) int
-	hooks       []func(string) int
-	history     []ContextParamIntFuncCall
+type ContextDeadlineFunc struct {
+	defaultHook func() (time.Time, bool)
+	hooks       []func() (time.Time, bool)
+	history     []ContextDeadlineFuncCall
 	mutex       sync.Mutex
 }
 
-// ParamInt delegates to the next hook function in the queue and stores the
+// Deadline delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockContext) ParamInt(v0 string) int {
-	r0 := m.ParamIntFunc.nextHook()(v0)
-	m.ParamIntFunc.appendCall(ContextParamIntFuncCall{v0, r0})
-	return r0
+func (m *MockContext) Deadline() (time.Time, bool) {
+	r0, r1 := m.DeadlineFunc.nextHook()()
+	m.DeadlineFunc.appendCall(ContextDeadlineFuncCall{r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ParamInt method of
+// SetDefaultHook sets function that is called when the Deadline method of
 // the parent MockContext instance is invoked and the hook queue is empty.
-func (f *ContextParamIntFunc) SetDefaultHook(hook func(string) int) {
+func (f *ContextDeadlineFunc) SetDefaultHook(hook func() (time.Time, bool)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ParamInt method of the parent MockContext instance invokes the hook at
+// Deadline method of the parent MockContext instance invokes the hook at
 // the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *ContextParamIntFunc) PushHook(hook func(string) int) {
+func (f *ContextDeadlineFunc) PushHook(hook func() (time.Time, bool)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1216,20 +1562,20 @@ func (f *ContextParamIntFunc) PushHook(hook func(string) int) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextParamIntFunc) SetDefaultReturn(r0 int) {
-	f.SetDefaultHook(func(string) int {
-		return r0
+func (f *ContextDeadlineFunc) SetDefaultReturn(r0 time.Time, r1 bool) {
+	f.SetDefaultHook(func() (time.Time, bool) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextParamIntFunc) PushReturn(r0 int) {
-	f.PushHook(func(string) int {
-		return r0
+func (f *ContextDeadlineFunc) PushReturn(r0 time.Time, r1 bool) {
+	f.PushHook(func() (time.Time, bool) {
+		return r0, r1
 	})
 }
 
-func (f *ContextParamIntFunc) nextHook() func(string) int {
+func (f *ContextDeadlineFunc) nextHook() func() (time.Time, bool) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1242,74 +1588,74 @@ func (f *ContextParamIntFunc) nextHook() func(string) int {
 	return hook
 }
 
-func (f *ContextParamIntFunc) appendCall(r0 ContextParamIntFuncCall) {
+func (f *ContextDeadlineFunc) appendCall(r0 ContextDeadlineFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextParamIntFuncCall objects describing
+// History returns a sequence of ContextDeadlineFuncCall objects describing
 // the invocations of this function.
-func (f *ContextParamIntFunc) History() []ContextParamIntFuncCall {
+func (f *ContextDeadlineFunc) History() []ContextDeadlineFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextParamIntFuncCall, len(f.history))
+	history := make([]ContextDeadlineFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextParamIntFuncCall is an object that describes an invocation of
-// method ParamInt on an instance of MockContext.
-type ContextParamIntFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 string
+// ContextDeadlineFuncCall is an object that describes an invocation of
+// method Deadline on an instance of MockContext.
+type ContextDeadlineFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int
+	Result0 time.Time
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ContextParamIntFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c ContextDeadlineFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextParamIntFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c ContextDeadlineFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// ContextParamInt64Func describes the behavior when the ParamInt64 method
-// of the parent MockContext instance is invoked.
-type ContextParamInt64Func struct {
-	defaultHook func(string) int64
-	hooks       []func(string) int64
-	history     []ContextParamInt64FuncCall
+// ContextDecorateFunc describes the behavior when the Decorate method of
+// the parent MockContext instance is invoked.
+type ContextDecorateFunc struct {
+	defaultHook func(interface{}) inject.Container
+	hooks       []func(interface{}) inject.Container
+	history     []ContextDecorateFuncCall
 	mutex       sync.Mutex
 }
 
-// ParamInt64 delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockContext) ParamInt64(v0 string) int64 {
-	r0 := m.ParamInt64Func.nextHook()(v0)
-	m.ParamInt64Func.appendCall(ContextParamInt64FuncCall{v0, r0})
+// Decorate delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Decorate(v0 interface{}) inject.Container {
+	r0 := m.DecorateFunc.nextHook()(v0)
+	m.DecorateFunc.appendCall(ContextDecorateFuncCall{v0, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the ParamInt64 method of
+// SetDefaultHook sets function that is called when the Decorate method of
 // the parent MockContext instance is invoked and the hook queue is empty.
-func (f *ContextParamInt64Func) SetDefaultHook(hook func(string) int64) {
+func (f *ContextDecorateFunc) SetDefaultHook(hook func(interface{}) inject.Container) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ParamInt64 method of the parent MockContext instance invokes the hook at
+// Decorate method of the parent MockContext instance invokes the hook at
 // the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *ContextParamInt64Func) PushHook(hook func(string) int64) {
+func (f *ContextDecorateFunc) PushHook(hook func(interface{}) inject.Container) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1317,20 +1663,20 @@ func (f *ContextParamInt64Func) PushHook(hook func(string) int64) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextParamInt64Func) SetDefaultReturn(r0 int64) {
-	f.SetDefaultHook(func(string) int64 {
+func (f *ContextDecorateFunc) SetDefaultReturn(r0 inject.Container) {
+	f.SetDefaultHook(func(interface{}) inject.Container {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextParamInt64Func) PushReturn(r0 int64) {
-	f.PushHook(func(string) int64 {
+func (f *ContextDecorateFunc) PushReturn(r0 inject.Container) {
+	f.PushHook(func(interface{}) inject.Container {
 		return r0
 	})
 }
 
-func (f *ContextParamInt64Func) nextHook() func(string) int64 {
+func (f *ContextDecorateFunc) nextHook() func(interface{}) inject.Container {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1343,74 +1689,74 @@ func (f *ContextParamInt64Func) nextHook() func(string) int64 {
 	return hook
 }
 
-func (f *ContextParamInt64Func) appendCall(r0 ContextParamInt64FuncCall) {
+func (f *ContextDecorateFunc) appendCall(r0 ContextDecorateFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextParamInt64FuncCall objects
-// describing the invocations of this function.
-func (f *ContextParamInt64Func) History() []ContextParamInt64FuncCall {
+// History returns a sequence of ContextDecorateFuncCall objects describing
+// the invocations of this function.
+func (f *ContextDecorateFunc) History() []ContextDecorateFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextParamInt64FuncCall, len(f.history))
+	history := make([]ContextDecorateFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextParamInt64FuncCall is an object that describes an invocation of
-// method ParamInt64 on an instance of MockContext.
-type ContextParamInt64FuncCall struct {
+// ContextDecorateFuncCall is an object that describes an invocation of
+// method Decorate on an instance of MockContext.
+type ContextDecorateFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 string
+	Arg0 interface{}
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int64
+	Result0 inject.Container
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ContextParamInt64FuncCall) Args() []interface{} {
+func (c ContextDecorateFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextParamInt64FuncCall) Results() []interface{} {
+func (c ContextDecorateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// ContextParamsFunc describes the behavior when the Params method of the
+// ContextDisposeFunc describes the behavior when the Dispose method of the
 // parent MockContext instance is invoked.
-type ContextParamsFunc struct {
-	defaultHook func() Params
-	hooks       []func() Params
-	history     []ContextParamsFuncCall
+type ContextDisposeFunc struct {
+	defaultHook func() error
+	hooks       []func() error
+	history     []ContextDisposeFuncCall
 	mutex       sync.Mutex
 }
 
-// Params delegates to the next hook function in the queue and stores the
+// Dispose delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockContext) Params() Params {
-	r0 := m.ParamsFunc.nextHook()()
-	m.ParamsFunc.appendCall(ContextParamsFuncCall{r0})
+func (m *MockContext) Dispose() error {
+	r0 := m.DisposeFunc.nextHook()()
+	m.DisposeFunc.appendCall(ContextDisposeFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Params method of the
-// parent MockContext instance is invoked and the hook queue is empty.
-func (f *ContextParamsFunc) SetDefaultHook(hook func() Params) {
+// SetDefaultHook sets function that is called when the Dispose method of
+// the parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextDisposeFunc) SetDefaultHook(hook func() error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Params method of the parent MockContext instance invokes the hook at the
+// Dispose method of the parent MockContext instance invokes the hook at the
 // front of the queue and discards it. After the queue is empty, the default
 // hook function is invoked for any future action.
-func (f *ContextParamsFunc) PushHook(hook func() Params) {
+func (f *ContextDisposeFunc) PushHook(hook func() error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1418,20 +1764,20 @@ func (f *ContextParamsFunc) PushHook(hook func() Params) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextParamsFunc) SetDefaultReturn(r0 Params) {
-	f.SetDefaultHook(func() Params {
+func (f *ContextDisposeFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func() error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextParamsFunc) PushReturn(r0 Params) {
-	f.PushHook(func() Params {
+func (f *ContextDisposeFunc) PushReturn(r0 error) {
+	f.PushHook(func() error {
 		return r0
 	})
 }
 
-func (f *ContextParamsFunc) nextHook() func() Params {
+func (f *ContextDisposeFunc) nextHook() func() error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1444,71 +1790,71 @@ func (f *ContextParamsFunc) nextHook() func() Params {
 	return hook
 }
 
-func (f *ContextParamsFunc) appendCall(r0 ContextParamsFuncCall) {
+func (f *ContextDisposeFunc) appendCall(r0 ContextDisposeFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextParamsFuncCall objects describing
+// History returns a sequence of ContextDisposeFuncCall objects describing
 // the invocations of this function.
-func (f *ContextParamsFunc) History() []ContextParamsFuncCall {
+func (f *ContextDisposeFunc) History() []ContextDisposeFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextParamsFuncCall, len(f.history))
+	history := make([]ContextDisposeFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextParamsFuncCall is an object that describes an invocation of method
-// Params on an instance of MockContext.
-type ContextParamsFuncCall struct {
+// ContextDisposeFuncCall is an object that describes an invocation of
+// method Dispose on an instance of MockContext.
+type ContextDisposeFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 Params
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ContextParamsFuncCall) Args() []interface{} {
+func (c ContextDisposeFuncCall) Args() []interface{} {
 	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextParamsFuncCall) Results() []interface{} {
+func (c ContextDisposeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// ContextQueryFunc describes the behavior when the Query method of the
-// parent MockContext instance is invoked.
-type ContextQueryFunc struct {
-	defaultHook func(string, ...string) string
-	hooks       []func(string, ...string) string
-	history     []ContextQueryFuncCall
+// ContextDoneFunc describes the behavior when the Done method of the parent
+// MockContext instance is invoked.
+type ContextDoneFunc struct {
+	defaultHook func() <-chan struct{}
+	hooks       []func() <-chan struct{}
+	history     []ContextDoneFuncCall
 	mutex       sync.Mutex
 }
 
-// Query delegates to the next hook function in the queue and stores the
+// Done delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockContext) Query(v0 string, v1 ...string) string {
-	r0 := m.QueryFunc.nextHook()(v0, v1...)
-	m.QueryFunc.appendCall(ContextQueryFuncCall{v0, v1, r0})
+func (m *MockContext) Done() <-chan struct{} {
+	r0 := m.DoneFunc.nextHook()()
+	m.DoneFunc.appendCall(ContextDoneFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Query method of the
+// SetDefaultHook sets function that is called when the Done method of the
 // parent MockContext instance is invoked and the hook queue is empty.
-func (f *ContextQueryFunc) SetDefaultHook(hook func(string, ...string) string) {
+func (f *ContextDoneFunc) SetDefaultHook(hook func() <-chan struct{}) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Query method of the parent MockContext instance invokes the hook at the
+// Done method of the parent MockContext instance invokes the hook at the
 // front of the queue and discards it. After the queue is empty, the default
 // hook function is invoked for any future action.
-func (f *ContextQueryFunc) PushHook(hook func(string, ...string) string) {
+func (f *ContextDoneFunc) PushHook(hook func() <-chan struct{}) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1516,20 +1862,20 @@ func (f *ContextQueryFunc) PushHook(hook func(string, ...string) string) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextQueryFunc) SetDefaultReturn(r0 string) {
-	f.SetDefaultHook(func(string, ...string) string {
+func (f *ContextDoneFunc) SetDefaultReturn(r0 <-chan struct{}) {
+	f.SetDefaultHook(func() <-chan struct{} {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextQueryFunc) PushReturn(r0 string) {
-	f.PushHook(func(string, ...string) string {
+func (f *ContextDoneFunc) PushReturn(r0 <-chan struct{}) {
+	f.PushHook(func() <-chan struct{} {
 		return r0
 	})
 }
 
-func (f *ContextQueryFunc) nextHook() func(string, ...string) string {
+func (f *ContextDoneFunc) nextHook() func() <-chan struct{} {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1542,84 +1888,71 @@ func (f *ContextQueryFunc) nextHook() func(string, ...string) string {
 	return hook
 }
 
-func (f *ContextQueryFunc) appendCall(r0 ContextQueryFuncCall) {
+func (f *ContextDoneFunc) appendCall(r0 ContextDoneFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextQueryFuncCall objects describing the
+// History returns a sequence of ContextDoneFuncCall objects describing the
 // invocations of this function.
-func (f *ContextQueryFunc) History() []ContextQueryFuncCall {
+func (f *ContextDoneFunc) History() []ContextDoneFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextQueryFuncCall, len(f.history))
+	history := make([]ContextDoneFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextQueryFuncCall is an object that describes an invocation of method
-// Query on an instance of MockContext.
-type ContextQueryFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 string
-	// Arg1 is a slice containing the values of the variadic arguments
-	// passed to this method invocation.
-	Arg1 []string
+// ContextDoneFuncCall is an object that describes an invocation of method
+// Done on an instance of MockContext.
+type ContextDoneFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 string
+	Result0 <-chan struct{}
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation. The variadic slice argument is flattened in this array such
-// that one positional argument and three variadic arguments would result in
-// a slice of four, not two.
-func (c ContextQueryFuncCall) Args() []interface{} {
-	trailing := []interface{}{}
-	for _, val := range c.Arg1 {
-		trailing = append(trailing, val)
-	}
-
-	return append([]interface{}{c.Arg0}, trailing...)
+// invocation.
+func (c ContextDoneFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextQueryFuncCall) Results() []interface{} {
+func (c ContextDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// ContextQueryBoolFunc describes the behavior when the QueryBool method of
-// the parent MockContext instance is invoked.
-type ContextQueryBoolFunc struct {
-	defaultHook func(string, ...bool) bool
-	hooks       []func(string, ...bool) bool
-	history     []ContextQueryBoolFuncCall
+// ContextErrFunc describes the behavior when the Err method of the parent
+// MockContext instance is invoked.
+type ContextErrFunc struct {
+	defaultHook func() error
+	hooks       []func() error
+	history     []ContextErrFuncCall
 	mutex       sync.Mutex
 }
 
-// QueryBool delegates to the next hook function in the queue and stores the
+// Err delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockContext) QueryBool(v0 string, v1 ...bool) bool {
-	r0 := m.QueryBoolFunc.nextHook()(v0, v1...)
-	m.QueryBoolFunc.appendCall(ContextQueryBoolFuncCall{v0, v1, r0})
+func (m *MockContext) Err() error {
+	r0 := m.ErrFunc.nextHook()()
+	m.ErrFunc.appendCall(ContextErrFuncCall{r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the QueryBool method of
-// the parent MockContext instance is invoked and the hook queue is empty.
-func (f *ContextQueryBoolFunc) SetDefaultHook(hook func(string, ...bool) bool) {
+// SetDefaultHook sets function that is called when the Err method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextErrFunc) SetDefaultHook(hook func() error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// QueryBool method of the parent MockContext instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *ContextQueryBoolFunc) PushHook(hook func(string, ...bool) bool) {
+// Err method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextErrFunc) PushHook(hook func() error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1627,20 +1960,20 @@ func (f *ContextQueryBoolFunc) PushHook(hook func(string, ...bool) bool) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextQueryBoolFunc) SetDefaultReturn(r0 bool) {
-	f.SetDefaultHook(func(string, ...bool) bool {
+func (f *ContextErrFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func() error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextQueryBoolFunc) PushReturn(r0 bool) {
-	f.PushHook(func(string, ...bool) bool {
+func (f *ContextErrFunc) PushReturn(r0 error) {
+	f.PushHook(func() error {
 		return r0
 	})
 }
 
-func (f *ContextQueryBoolFunc) nextHook() func(string, ...bool) bool {
+func (f *ContextErrFunc) nextHook() func() error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1653,85 +1986,71 @@ func (f *ContextQueryBoolFunc) nextHook() func(string, ...bool) bool {
 	return hook
 }
 
-func (f *ContextQueryBoolFunc) appendCall(r0 ContextQueryBoolFuncCall) {
+func (f *ContextErrFunc) appendCall(r0 ContextErrFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextQueryBoolFuncCall objects describing
-// the invocations of this function.
-func (f *ContextQueryBoolFunc) History() []ContextQueryBoolFuncCall {
+// History returns a sequence of ContextErrFuncCall objects describing the
+// invocations of this function.
+func (f *ContextErrFunc) History() []ContextErrFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextQueryBoolFuncCall, len(f.history))
+	history := make([]ContextErrFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextQueryBoolFuncCall is an object that describes an invocation of
-// method QueryBool on an instance of MockContext.
-type ContextQueryBoolFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 string
-	// Arg1 is a slice containing the values of the variadic arguments
-	// passed to this method invocation.
-	Arg1 []bool
+// ContextErrFuncCall is an object that describes an invocation of method
+// Err on an instance of MockContext.
+type ContextErrFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 bool
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation. The variadic slice argument is flattened in this array such
-// that one positional argument and three variadic arguments would result in
-// a slice of four, not two.
-func (c ContextQueryBoolFuncCall) Args() []interface{} {
-	trailing := []interface{}{}
-	for _, val := range c.Arg1 {
-		trailing = append(trailing, val)
-	}
-
-	return append([]interface{}{c.Arg0}, trailing...)
+// invocation.
+func (c ContextErrFuncCall) Args() []interface{} {
+	return []interface{}{}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextQueryBoolFuncCall) Results() []interface{} {
+func (c ContextErrFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// ContextQueryFloat64Func describes the behavior when the QueryFloat64
-// method of the parent MockContext instance is invoked.
-type ContextQueryFloat64Func struct {
-	defaultHook func(string, ...float64) float64
-	hooks       []func(string, ...float64) float64
-	history     []ContextQueryFloat64FuncCall
+// ContextErrorFunc describes the behavior when the Error method of the
+// parent MockContext instance is invoked.
+type ContextErrorFunc struct {
+	defaultHook func(error)
+	hooks       []func(error)
+	history     []ContextErrorFuncCall
 	mutex       sync.Mutex
 }
 
-// QueryFloat64 delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockContext) QueryFloat64(v0 string, v1 ...float64) float64 {
-	r0 := m.QueryFloat64Func.nextHook()(v0, v1...)
-	m.QueryFloat64Func.appendCall(ContextQueryFloat64FuncCall{v0, v1, r0})
-	return r0
+// Error delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Error(v0 error) {
+	m.ErrorFunc.nextHook()(v0)
+	m.ErrorFunc.appendCall(ContextErrorFuncCall{v0})
+	return
 }
 
-// SetDefaultHook sets function that is called when the QueryFloat64 method
-// of the parent MockContext instance is invoked and the hook queue is
-// empty.
-func (f *ContextQueryFloat64Func) SetDefaultHook(hook func(string, ...float64) float64) {
+// SetDefaultHook sets function that is called when the Error method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextErrorFunc) SetDefaultHook(hook func(error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// QueryFloat64 method of the parent MockContext instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *ContextQueryFloat64Func) PushHook(hook func(string, ...float64) float64) {
+// Error method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextErrorFunc) PushHook(hook func(error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1739,20 +2058,20 @@ func (f *ContextQueryFloat64Func) PushHook(hook func(string, ...float64) float64
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextQueryFloat64Func) SetDefaultReturn(r0 float64) {
-	f.SetDefaultHook(func(string, ...float64) float64 {
-		return r0
+func (f *ContextErrorFunc) SetDefaultReturn() {
+	f.SetDefaultHook(func(error) {
+		return
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextQueryFloat64Func) PushReturn(r0 float64) {
-	f.PushHook(func(string, ...float64) float64 {
-		return r0
+func (f *ContextErrorFunc) PushReturn() {
+	f.PushHook(func(error) {
+		return
 	})
 }
 
-func (f *ContextQueryFloat64Func) nextHook() func(string, ...float64) float64 {
+func (f *ContextErrorFunc) nextHook() func(error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1765,43 +2084,1890 @@ func (f *ContextQueryFloat64Func) nextHook() func(string, ...float64) float64 {
 	return hook
 }
 
-func (f *ContextQueryFloat64Func) appendCall(r0 ContextQueryFloat64FuncCall) {
+func (f *ContextErrorFunc) appendCall(r0 ContextErrorFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextQueryFloat64FuncCall objects
-// describing the invocations of this function.
-func (f *ContextQueryFloat64Func) History() []ContextQueryFloat64FuncCall {
+// History returns a sequence of ContextErrorFuncCall objects describing the
+// invocations of this function.
+func (f *ContextErrorFunc) History() []ContextErrorFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextQueryFloat64FuncCall, len(f.history))
+	history := make([]ContextErrorFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextQueryFloat64FuncCall is an object that describes an invocation of
-// method QueryFloat64 on an instance of MockContext.
-type ContextQueryFloat64FuncCall struct {
+// ContextErrorFuncCall is an object that describes an invocation of method
+// Error on an instance of MockContext.
+type ContextErrorFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 string
-	// Arg1 is a slice containing the values of the variadic arguments
-	// passed to this method invocation.
-	Arg1 []float64
-	// Result0 is the value of the 1st result returned from this method
-	// invocation.
-	Result0 float64
+	Arg0 error
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation. The variadic slice argument is flattened in this array such
-// that one positional argument and three variadic arguments would result in
-// a slice of four, not two.
-func (c ContextQueryFloat64FuncCall) Args() []interface{} {
-	trailing := []interface{}{}
+// invocation.
+func (c ContextErrorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextErrorFuncCall) Results() []interface{} {
+	return []interface{}{}
+}
+
+// ContextGetValueFunc describes the behavior when the GetValue method of
+// the parent MockContext instance is invoked.
+type ContextGetValueFunc struct {
+	defaultHook func(interface{}) (interface{}, bool)
+	hooks       []func(interface{}) (interface{}, bool)
+	history     []ContextGetValueFuncCall
+	mutex       sync.Mutex
+}
+
+// GetValue delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) GetValue(v0 interface{}) (interface{}, bool) {
+	r0, r1 := m.GetValueFunc.nextHook()(v0)
+	m.GetValueFunc.appendCall(ContextGetValueFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetValue method of
+// the parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextGetValueFunc) SetDefaultHook(hook func(interface{}) (interface{}, bool)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetValue method of the parent MockContext instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ContextGetValueFunc) PushHook(hook func(interface{}) (interface{}, bool)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextGetValueFunc) SetDefaultReturn(r0 interface{}, r1 bool) {
+	f.SetDefaultHook(func(interface{}) (interface{}, bool) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextGetValueFunc) PushReturn(r0 interface{}, r1 bool) {
+	f.PushHook(func(interface{}) (interface{}, bool) {
+		return r0, r1
+	})
+}
+
+func (f *ContextGetValueFunc) nextHook() func(interface{}) (interface{}, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextGetValueFunc) appendCall(r0 ContextGetValueFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextGetValueFuncCall objects describing
+// the invocations of this function.
+func (f *ContextGetValueFunc) History() []ContextGetValueFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextGetValueFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextGetValueFuncCall is an object that describes an invocation of
+// method GetValue on an instance of MockContext.
+type ContextGetValueFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 interface{}
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 interface{}
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextGetValueFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextGetValueFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ContextHandlerTracesFunc describes the behavior when the HandlerTraces
+// method of the parent MockContext instance is invoked.
+type ContextHandlerTracesFunc struct {
+	defaultHook func() []HandlerTrace
+	hooks       []func() []HandlerTrace
+	history     []ContextHandlerTracesFuncCall
+	mutex       sync.Mutex
+}
+
+// HandlerTraces delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockContext) HandlerTraces() []HandlerTrace {
+	r0 := m.HandlerTracesFunc.nextHook()()
+	m.HandlerTracesFunc.appendCall(ContextHandlerTracesFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the HandlerTraces method
+// of the parent MockContext instance is invoked and the hook queue is
+// empty.
+func (f *ContextHandlerTracesFunc) SetDefaultHook(hook func() []HandlerTrace) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// HandlerTraces method of the parent MockContext instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ContextHandlerTracesFunc) PushHook(hook func() []HandlerTrace) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextHandlerTracesFunc) SetDefaultReturn(r0 []HandlerTrace) {
+	f.SetDefaultHook(func() []HandlerTrace {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextHandlerTracesFunc) PushReturn(r0 []HandlerTrace) {
+	f.PushHook(func() []HandlerTrace {
+		return r0
+	})
+}
+
+func (f *ContextHandlerTracesFunc) nextHook() func() []HandlerTrace {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextHandlerTracesFunc) appendCall(r0 ContextHandlerTracesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextHandlerTracesFuncCall objects
+// describing the invocations of this function.
+func (f *ContextHandlerTracesFunc) History() []ContextHandlerTracesFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextHandlerTracesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextHandlerTracesFuncCall is an object that describes an invocation of
+// method HandlerTraces on an instance of MockContext.
+type ContextHandlerTracesFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []HandlerTrace
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextHandlerTracesFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextHandlerTracesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextHasFunc describes the behavior when the Has method of the parent
+// MockContext instance is invoked.
+type ContextHasFunc struct {
+	defaultHook func(reflect.Type) bool
+	hooks       []func(reflect.Type) bool
+	history     []ContextHasFuncCall
+	mutex       sync.Mutex
+}
+
+// Has delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Has(v0 reflect.Type) bool {
+	r0 := m.HasFunc.nextHook()(v0)
+	m.HasFunc.appendCall(ContextHasFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Has method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextHasFunc) SetDefaultHook(hook func(reflect.Type) bool) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Has method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextHasFunc) PushHook(hook func(reflect.Type) bool) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextHasFunc) SetDefaultReturn(r0 bool) {
+	f.SetDefaultHook(func(reflect.Type) bool {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextHasFunc) PushReturn(r0 bool) {
+	f.PushHook(func(reflect.Type) bool {
+		return r0
+	})
+}
+
+func (f *ContextHasFunc) nextHook() func(reflect.Type) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextHasFunc) appendCall(r0 ContextHasFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextHasFuncCall objects describing the
+// invocations of this function.
+func (f *ContextHasFunc) History() []ContextHasFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextHasFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextHasFuncCall is an object that describes an invocation of method
+// Has on an instance of MockContext.
+type ContextHasFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 reflect.Type
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextHasFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextHasFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextHostFunc describes the behavior when the Host method of the parent
+// MockContext instance is invoked.
+type ContextHostFunc struct {
+	defaultHook func() string
+	hooks       []func() string
+	history     []ContextHostFuncCall
+	mutex       sync.Mutex
+}
+
+// Host delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Host() string {
+	r0 := m.HostFunc.nextHook()()
+	m.HostFunc.appendCall(ContextHostFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Host method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextHostFunc) SetDefaultHook(hook func() string) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Host method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextHostFunc) PushHook(hook func() string) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextHostFunc) SetDefaultReturn(r0 string) {
+	f.SetDefaultHook(func() string {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextHostFunc) PushReturn(r0 string) {
+	f.PushHook(func() string {
+		return r0
+	})
+}
+
+func (f *ContextHostFunc) nextHook() func() string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextHostFunc) appendCall(r0 ContextHostFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextHostFuncCall objects describing the
+// invocations of this function.
+func (f *ContextHostFunc) History() []ContextHostFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextHostFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextHostFuncCall is an object that describes an invocation of method
+// Host on an instance of MockContext.
+type ContextHostFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextHostFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextHostFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextInvokeFunc describes the behavior when the Invoke method of the
+// parent MockContext instance is invoked.
+type ContextInvokeFunc struct {
+	defaultHook func(interface{}) ([]reflect.Value, error)
+	hooks       []func(interface{}) ([]reflect.Value, error)
+	history     []ContextInvokeFuncCall
+	mutex       sync.Mutex
+}
+
+// Invoke delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Invoke(v0 interface{}) ([]reflect.Value, error) {
+	r0, r1 := m.InvokeFunc.nextHook()(v0)
+	m.InvokeFunc.appendCall(ContextInvokeFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Invoke method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextInvokeFunc) SetDefaultHook(hook func(interface{}) ([]reflect.Value, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Invoke method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextInvokeFunc) PushHook(hook func(interface{}) ([]reflect.Value, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextInvokeFunc) SetDefaultReturn(r0 []reflect.Value, r1 error) {
+	f.SetDefaultHook(func(interface{}) ([]reflect.Value, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextInvokeFunc) PushReturn(r0 []reflect.Value, r1 error) {
+	f.PushHook(func(interface{}) ([]reflect.Value, error) {
+		return r0, r1
+	})
+}
+
+func (f *ContextInvokeFunc) nextHook() func(interface{}) ([]reflect.Value, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextInvokeFunc) appendCall(r0 ContextInvokeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextInvokeFuncCall objects describing
+// the invocations of this function.
+func (f *ContextInvokeFunc) History() []ContextInvokeFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextInvokeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextInvokeFuncCall is an object that describes an invocation of method
+// Invoke on an instance of MockContext.
+type ContextInvokeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 interface{}
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []reflect.Value
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextInvokeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextInvokeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ContextIsAbortedFunc describes the behavior when the IsAborted method of
+// the parent MockContext instance is invoked.
+type ContextIsAbortedFunc struct {
+	defaultHook func() bool
+	hooks       []func() bool
+	history     []ContextIsAbortedFuncCall
+	mutex       sync.Mutex
+}
+
+// IsAborted delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) IsAborted() bool {
+	r0 := m.IsAbortedFunc.nextHook()()
+	m.IsAbortedFunc.appendCall(ContextIsAbortedFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the IsAborted method of
+// the parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextIsAbortedFunc) SetDefaultHook(hook func() bool) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IsAborted method of the parent MockContext instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ContextIsAbortedFunc) PushHook(hook func() bool) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextIsAbortedFunc) SetDefaultReturn(r0 bool) {
+	f.SetDefaultHook(func() bool {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextIsAbortedFunc) PushReturn(r0 bool) {
+	f.PushHook(func() bool {
+		return r0
+	})
+}
+
+func (f *ContextIsAbortedFunc) nextHook() func() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextIsAbortedFunc) appendCall(r0 ContextIsAbortedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextIsAbortedFuncCall objects describing
+// the invocations of this function.
+func (f *ContextIsAbortedFunc) History() []ContextIsAbortedFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextIsAbortedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextIsAbortedFuncCall is an object that describes an invocation of
+// method IsAborted on an instance of MockContext.
+type ContextIsAbortedFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextIsAbortedFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextIsAbortedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextMapFunc describes the behavior when the Map method of the parent
+// MockContext instance is invoked.
+type ContextMapFunc struct {
+	defaultHook func(...interface{}) inject.TypeMapper
+	hooks       []func(...interface{}) inject.TypeMapper
+	history     []ContextMapFuncCall
+	mutex       sync.Mutex
+}
+
+// Map delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Map(v0 ...interface{}) inject.TypeMapper {
+	r0 := m.MapFunc.nextHook()(v0...)
+	m.MapFunc.appendCall(ContextMapFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Map method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextMapFunc) SetDefaultHook(hook func(...interface{}) inject.TypeMapper) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Map method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextMapFunc) PushHook(hook func(...interface{}) inject.TypeMapper) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextMapFunc) SetDefaultReturn(r0 inject.TypeMapper) {
+	f.SetDefaultHook(func(...interface{}) inject.TypeMapper {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextMapFunc) PushReturn(r0 inject.TypeMapper) {
+	f.PushHook(func(...interface{}) inject.TypeMapper {
+		return r0
+	})
+}
+
+func (f *ContextMapFunc) nextHook() func(...interface{}) inject.TypeMapper {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextMapFunc) appendCall(r0 ContextMapFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextMapFuncCall objects describing the
+// invocations of this function.
+func (f *ContextMapFunc) History() []ContextMapFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextMapFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextMapFuncCall is an object that describes an invocation of method
+// Map on an instance of MockContext.
+type ContextMapFuncCall struct {
+	// Arg0 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg0 []interface{}
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 inject.TypeMapper
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c ContextMapFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg0 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextMapFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextMapNamedFunc describes the behavior when the MapNamed method of
+// the parent MockContext instance is invoked.
+type ContextMapNamedFunc struct {
+	defaultHook func(string, interface{}) inject.Container
+	hooks       []func(string, interface{}) inject.Container
+	history     []ContextMapNamedFuncCall
+	mutex       sync.Mutex
+}
+
+// MapNamed delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) MapNamed(v0 string, v1 interface{}) inject.Container {
+	r0 := m.MapNamedFunc.nextHook()(v0, v1)
+	m.MapNamedFunc.appendCall(ContextMapNamedFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the MapNamed method of
+// the parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextMapNamedFunc) SetDefaultHook(hook func(string, interface{}) inject.Container) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MapNamed method of the parent MockContext instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ContextMapNamedFunc) PushHook(hook func(string, interface{}) inject.Container) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextMapNamedFunc) SetDefaultReturn(r0 inject.Container) {
+	f.SetDefaultHook(func(string, interface{}) inject.Container {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextMapNamedFunc) PushReturn(r0 inject.Container) {
+	f.PushHook(func(string, interface{}) inject.Container {
+		return r0
+	})
+}
+
+func (f *ContextMapNamedFunc) nextHook() func(string, interface{}) inject.Container {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextMapNamedFunc) appendCall(r0 ContextMapNamedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextMapNamedFuncCall objects describing
+// the invocations of this function.
+func (f *ContextMapNamedFunc) History() []ContextMapNamedFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextMapNamedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextMapNamedFuncCall is an object that describes an invocation of
+// method MapNamed on an instance of MockContext.
+type ContextMapNamedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 string
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 interface{}
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 inject.Container
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextMapNamedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextMapNamedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextMapToFunc describes the behavior when the MapTo method of the
+// parent MockContext instance is invoked.
+type ContextMapToFunc struct {
+	defaultHook func(interface{}, interface{}) inject.TypeMapper
+	hooks       []func(interface{}, interface{}) inject.TypeMapper
+	history     []ContextMapToFuncCall
+	mutex       sync.Mutex
+}
+
+// MapTo delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) MapTo(v0 interface{}, v1 interface{}) inject.TypeMapper {
+	r0 := m.MapToFunc.nextHook()(v0, v1)
+	m.MapToFunc.appendCall(ContextMapToFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the MapTo method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextMapToFunc) SetDefaultHook(hook func(interface{}, interface{}) inject.TypeMapper) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MapTo method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextMapToFunc) PushHook(hook func(interface{}, interface{}) inject.TypeMapper) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextMapToFunc) SetDefaultReturn(r0 inject.TypeMapper) {
+	f.SetDefaultHook(func(interface{}, interface{}) inject.TypeMapper {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextMapToFunc) PushReturn(r0 inject.TypeMapper) {
+	f.PushHook(func(interface{}, interface{}) inject.TypeMapper {
+		return r0
+	})
+}
+
+func (f *ContextMapToFunc) nextHook() func(interface{}, interface{}) inject.TypeMapper {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextMapToFunc) appendCall(r0 ContextMapToFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextMapToFuncCall objects describing the
+// invocations of this function.
+func (f *ContextMapToFunc) History() []ContextMapToFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextMapToFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextMapToFuncCall is an object that describes an invocation of method
+// MapTo on an instance of MockContext.
+type ContextMapToFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 interface{}
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 interface{}
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 inject.TypeMapper
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextMapToFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextMapToFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextNextFunc describes the behavior when the Next method of the parent
+// MockContext instance is invoked.
+type ContextNextFunc struct {
+	defaultHook func()
+	hooks       []func()
+	history     []ContextNextFuncCall
+	mutex       sync.Mutex
+}
+
+// Next delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Next() {
+	m.NextFunc.nextHook()()
+	m.NextFunc.appendCall(ContextNextFuncCall{})
+	return
+}
+
+// SetDefaultHook sets function that is called when the Next method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextNextFunc) SetDefaultHook(hook func()) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Next method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextNextFunc) PushHook(hook func()) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextNextFunc) SetDefaultReturn() {
+	f.SetDefaultHook(func() {
+		return
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextNextFunc) PushReturn() {
+	f.PushHook(func() {
+		return
+	})
+}
+
+func (f *ContextNextFunc) nextHook() func() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextNextFunc) appendCall(r0 ContextNextFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextNextFuncCall objects describing the
+// invocations of this function.
+func (f *ContextNextFunc) History() []ContextNextFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextNextFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextNextFuncCall is an object that describes an invocation of method
+// Next on an instance of MockContext.
+type ContextNextFuncCall struct{}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextNextFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextNextFuncCall) Results() []interface{} {
+	return []interface{}{}
+}
+
+// ContextParamFunc describes the behavior when the Param method of the
+// parent MockContext instance is invoked.
+type ContextParamFunc struct {
+	defaultHook func(string) string
+	hooks       []func(string) string
+	history     []ContextParamFuncCall
+	mutex       sync.Mutex
+}
+
+// Param delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Param(v0 string) string {
+	r0 := m.ParamFunc.nextHook()(v0)
+	m.ParamFunc.appendCall(ContextParamFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Param method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextParamFunc) SetDefaultHook(hook func(string) string) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Param method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextParamFunc) PushHook(hook func(string) string) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextParamFunc) SetDefaultReturn(r0 string) {
+	f.SetDefaultHook(func(string) string {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextParamFunc) PushReturn(r0 string) {
+	f.PushHook(func(string) string {
+		return r0
+	})
+}
+
+func (f *ContextParamFunc) nextHook() func(string) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextParamFunc) appendCall(r0 ContextParamFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextParamFuncCall objects describing the
+// invocations of this function.
+func (f *ContextParamFunc) History() []ContextParamFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextParamFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextParamFuncCall is an object that describes an invocation of method
+// Param on an instance of MockContext.
+type ContextParamFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextParamFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextParamFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextParamIntFunc describes the behavior when the ParamInt method of
+// the parent MockContext instance is invoked.
+type ContextParamIntFunc struct {
+	defaultHook func(string) int
+	hooks       []func(string) int
+	history     []ContextParamIntFuncCall
+	mutex       sync.Mutex
+}
+
+// ParamInt delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) ParamInt(v0 string) int {
+	r0 := m.ParamIntFunc.nextHook()(v0)
+	m.ParamIntFunc.appendCall(ContextParamIntFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ParamInt method of
+// the parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextParamIntFunc) SetDefaultHook(hook func(string) int) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ParamInt method of the parent MockContext instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ContextParamIntFunc) PushHook(hook func(string) int) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextParamIntFunc) SetDefaultReturn(r0 int) {
+	f.SetDefaultHook(func(string) int {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextParamIntFunc) PushReturn(r0 int) {
+	f.PushHook(func(string) int {
+		return r0
+	})
+}
+
+func (f *ContextParamIntFunc) nextHook() func(string) int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextParamIntFunc) appendCall(r0 ContextParamIntFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextParamIntFuncCall objects describing
+// the invocations of this function.
+func (f *ContextParamIntFunc) History() []ContextParamIntFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextParamIntFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextParamIntFuncCall is an object that describes an invocation of
+// method ParamInt on an instance of MockContext.
+type ContextParamIntFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextParamIntFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextParamIntFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextParamInt64Func describes the behavior when the ParamInt64 method
+// of the parent MockContext instance is invoked.
+type ContextParamInt64Func struct {
+	defaultHook func(string) int64
+	hooks       []func(string) int64
+	history     []ContextParamInt64FuncCall
+	mutex       sync.Mutex
+}
+
+// ParamInt64 delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockContext) ParamInt64(v0 string) int64 {
+	r0 := m.ParamInt64Func.nextHook()(v0)
+	m.ParamInt64Func.appendCall(ContextParamInt64FuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ParamInt64 method of
+// the parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextParamInt64Func) SetDefaultHook(hook func(string) int64) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ParamInt64 method of the parent MockContext instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ContextParamInt64Func) PushHook(hook func(string) int64) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextParamInt64Func) SetDefaultReturn(r0 int64) {
+	f.SetDefaultHook(func(string) int64 {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextParamInt64Func) PushReturn(r0 int64) {
+	f.PushHook(func(string) int64 {
+		return r0
+	})
+}
+
+func (f *ContextParamInt64Func) nextHook() func(string) int64 {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextParamInt64Func) appendCall(r0 ContextParamInt64FuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextParamInt64FuncCall objects
+// describing the invocations of this function.
+func (f *ContextParamInt64Func) History() []ContextParamInt64FuncCall {
+	f.mutex.Lock()
+	history := make([]ContextParamInt64FuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextParamInt64FuncCall is an object that describes an invocation of
+// method ParamInt64 on an instance of MockContext.
+type ContextParamInt64FuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int64
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextParamInt64FuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextParamInt64FuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextParamsFunc describes the behavior when the Params method of the
+// parent MockContext instance is invoked.
+type ContextParamsFunc struct {
+	defaultHook func() Params
+	hooks       []func() Params
+	history     []ContextParamsFuncCall
+	mutex       sync.Mutex
+}
+
+// Params delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Params() Params {
+	r0 := m.ParamsFunc.nextHook()()
+	m.ParamsFunc.appendCall(ContextParamsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Params method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextParamsFunc) SetDefaultHook(hook func() Params) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Params method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextParamsFunc) PushHook(hook func() Params) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextParamsFunc) SetDefaultReturn(r0 Params) {
+	f.SetDefaultHook(func() Params {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextParamsFunc) PushReturn(r0 Params) {
+	f.PushHook(func() Params {
+		return r0
+	})
+}
+
+func (f *ContextParamsFunc) nextHook() func() Params {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextParamsFunc) appendCall(r0 ContextParamsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextParamsFuncCall objects describing
+// the invocations of this function.
+func (f *ContextParamsFunc) History() []ContextParamsFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextParamsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextParamsFuncCall is an object that describes an invocation of method
+// Params on an instance of MockContext.
+type ContextParamsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 Params
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextParamsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextParamsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextProvideFunc describes the behavior when the Provide method of the
+// parent MockContext instance is invoked.
+type ContextProvideFunc struct {
+	defaultHook func(interface{}, ...inject.Lifetime) inject.Container
+	hooks       []func(interface{}, ...inject.Lifetime) inject.Container
+	history     []ContextProvideFuncCall
+	mutex       sync.Mutex
+}
+
+// Provide delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Provide(v0 interface{}, v1 ...inject.Lifetime) inject.Container {
+	r0 := m.ProvideFunc.nextHook()(v0, v1...)
+	m.ProvideFunc.appendCall(ContextProvideFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Provide method of
+// the parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextProvideFunc) SetDefaultHook(hook func(interface{}, ...inject.Lifetime) inject.Container) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Provide method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextProvideFunc) PushHook(hook func(interface{}, ...inject.Lifetime) inject.Container) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextProvideFunc) SetDefaultReturn(r0 inject.Container) {
+	f.SetDefaultHook(func(interface{}, ...inject.Lifetime) inject.Container {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextProvideFunc) PushReturn(r0 inject.Container) {
+	f.PushHook(func(interface{}, ...inject.Lifetime) inject.Container {
+		return r0
+	})
+}
+
+func (f *ContextProvideFunc) nextHook() func(interface{}, ...inject.Lifetime) inject.Container {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextProvideFunc) appendCall(r0 ContextProvideFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextProvideFuncCall objects describing
+// the invocations of this function.
+func (f *ContextProvideFunc) History() []ContextProvideFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextProvideFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextProvideFuncCall is an object that describes an invocation of
+// method Provide on an instance of MockContext.
+type ContextProvideFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 interface{}
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []inject.Lifetime
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 inject.Container
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c ContextProvideFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextProvideFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextQueryFunc describes the behavior when the Query method of the
+// parent MockContext instance is invoked.
+type ContextQueryFunc struct {
+	defaultHook func(string, ...string) string
+	hooks       []func(string, ...string) string
+	history     []ContextQueryFuncCall
+	mutex       sync.Mutex
+}
+
+// Query delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Query(v0 string, v1 ...string) string {
+	r0 := m.QueryFunc.nextHook()(v0, v1...)
+	m.QueryFunc.appendCall(ContextQueryFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Query method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextQueryFunc) SetDefaultHook(hook func(string, ...string) string) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Query method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextQueryFunc) PushHook(hook func(string, ...string) string) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextQueryFunc) SetDefaultReturn(r0 string) {
+	f.SetDefaultHook(func(string, ...string) string {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextQueryFunc) PushReturn(r0 string) {
+	f.PushHook(func(string, ...string) string {
+		return r0
+	})
+}
+
+func (f *ContextQueryFunc) nextHook() func(string, ...string) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextQueryFunc) appendCall(r0 ContextQueryFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextQueryFuncCall objects describing the
+// invocations of this function.
+func (f *ContextQueryFunc) History() []ContextQueryFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextQueryFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextQueryFuncCall is an object that describes an invocation of method
+// Query on an instance of MockContext.
+type ContextQueryFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 string
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c ContextQueryFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextQueryFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextQueryBoolFunc describes the behavior when the QueryBool method of
+// the parent MockContext instance is invoked.
+type ContextQueryBoolFunc struct {
+	defaultHook func(string, ...bool) bool
+	hooks       []func(string, ...bool) bool
+	history     []ContextQueryBoolFuncCall
+	mutex       sync.Mutex
+}
+
+// QueryBool delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) QueryBool(v0 string, v1 ...bool) bool {
+	r0 := m.QueryBoolFunc.nextHook()(v0, v1...)
+	m.QueryBoolFunc.appendCall(ContextQueryBoolFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the QueryBool method of
+// the parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextQueryBoolFunc) SetDefaultHook(hook func(string, ...bool) bool) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// QueryBool method of the parent MockContext instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ContextQueryBoolFunc) PushHook(hook func(string, ...bool) bool) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextQueryBoolFunc) SetDefaultReturn(r0 bool) {
+	f.SetDefaultHook(func(string, ...bool) bool {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextQueryBoolFunc) PushReturn(r0 bool) {
+	f.PushHook(func(string, ...bool) bool {
+		return r0
+	})
+}
+
+func (f *ContextQueryBoolFunc) nextHook() func(string, ...bool) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextQueryBoolFunc) appendCall(r0 ContextQueryBoolFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextQueryBoolFuncCall objects describing
+// the invocations of this function.
+func (f *ContextQueryBoolFunc) History() []ContextQueryBoolFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextQueryBoolFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextQueryBoolFuncCall is an object that describes an invocation of
+// method QueryBool on an instance of MockContext.
+type ContextQueryBoolFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 string
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c ContextQueryBoolFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextQueryBoolFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextQueryFloat64Func describes the behavior when the QueryFloat64
+// method of the parent MockContext instance is invoked.
+type ContextQueryFloat64Func struct {
+	defaultHook func(string, ...float64) float64
+	hooks       []func(string, ...float64) float64
+	history     []ContextQueryFloat64FuncCall
+	mutex       sync.Mutex
+}
+
+// QueryFloat64 delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockContext) QueryFloat64(v0 string, v1 ...float64) float64 {
+	r0 := m.QueryFloat64Func.nextHook()(v0, v1...)
+	m.QueryFloat64Func.appendCall(ContextQueryFloat64FuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the QueryFloat64 method
+// of the parent MockContext instance is invoked and the hook queue is
+// empty.
+func (f *ContextQueryFloat64Func) SetDefaultHook(hook func(string, ...float64) float64) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// QueryFloat64 method of the parent MockContext instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ContextQueryFloat64Func) PushHook(hook func(string, ...float64) float64) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextQueryFloat64Func) SetDefaultReturn(r0 float64) {
+	f.SetDefaultHook(func(string, ...float64) float64 {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextQueryFloat64Func) PushReturn(r0 float64) {
+	f.PushHook(func(string, ...float64) float64 {
+		return r0
+	})
+}
+
+func (f *ContextQueryFloat64Func) nextHook() func(string, ...float64) float64 {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextQueryFloat64Func) appendCall(r0 ContextQueryFloat64FuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextQueryFloat64FuncCall objects
+// describing the invocations of this function.
+func (f *ContextQueryFloat64Func) History() []ContextQueryFloat64FuncCall {
+	f.mutex.Lock()
+	history := make([]ContextQueryFloat64FuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextQueryFloat64FuncCall is an object that describes an invocation of
+// method QueryFloat64 on an instance of MockContext.
+type ContextQueryFloat64FuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 string
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []float64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 float64
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c ContextQueryFloat64FuncCall) Args() []interface{} {
+	trailing := []interface{}{}
 	for _, val := range c.Arg1 {
 		trailing = append(trailing, val)
 	}
@@ -2775,6 +4941,104 @@ func (c ContextResponseWriterFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ContextSchemeFunc describes the behavior when the Scheme method of the
+// parent MockContext instance is invoked.
+type ContextSchemeFunc struct {
+	defaultHook func() string
+	hooks       []func() string
+	history     []ContextSchemeFuncCall
+	mutex       sync.Mutex
+}
+
+// Scheme delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Scheme() string {
+	r0 := m.SchemeFunc.nextHook()()
+	m.SchemeFunc.appendCall(ContextSchemeFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Scheme method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextSchemeFunc) SetDefaultHook(hook func() string) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Scheme method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextSchemeFunc) PushHook(hook func() string) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextSchemeFunc) SetDefaultReturn(r0 string) {
+	f.SetDefaultHook(func() string {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextSchemeFunc) PushReturn(r0 string) {
+	f.PushHook(func() string {
+		return r0
+	})
+}
+
+func (f *ContextSchemeFunc) nextHook() func() string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextSchemeFunc) appendCall(r0 ContextSchemeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextSchemeFuncCall objects describing
+// the invocations of this function.
+func (f *ContextSchemeFunc) History() []ContextSchemeFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextSchemeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextSchemeFuncCall is an object that describes an invocation of method
+// Scheme on an instance of MockContext.
+type ContextSchemeFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextSchemeFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextSchemeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ContextSetFunc describes the behavior when the Set method of the parent
 // MockContext instance is invoked.
 type ContextSetFunc struct {
@@ -2962,49 +5226,147 @@ func (f *ContextSetCookieFunc) History() []ContextSetCookieFuncCall {
 type ContextSetCookieFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 http.Cookie
+	Arg0 http.Cookie
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextSetCookieFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextSetCookieFuncCall) Results() []interface{} {
+	return []interface{}{}
+}
+
+// ContextSetParentFunc describes the behavior when the SetParent method of
+// the parent MockContext instance is invoked.
+type ContextSetParentFunc struct {
+	defaultHook func(inject.Injector)
+	hooks       []func(inject.Injector)
+	history     []ContextSetParentFuncCall
+	mutex       sync.Mutex
+}
+
+// SetParent delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) SetParent(v0 inject.Injector) {
+	m.SetParentFunc.nextHook()(v0)
+	m.SetParentFunc.appendCall(ContextSetParentFuncCall{v0})
+	return
+}
+
+// SetDefaultHook sets function that is called when the SetParent method of
+// the parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextSetParentFunc) SetDefaultHook(hook func(inject.Injector)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetParent method of the parent MockContext instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ContextSetParentFunc) PushHook(hook func(inject.Injector)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextSetParentFunc) SetDefaultReturn() {
+	f.SetDefaultHook(func(inject.Injector) {
+		return
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextSetParentFunc) PushReturn() {
+	f.PushHook(func(inject.Injector) {
+		return
+	})
+}
+
+func (f *ContextSetParentFunc) nextHook() func(inject.Injector) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextSetParentFunc) appendCall(r0 ContextSetParentFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextSetParentFuncCall objects describing
+// the invocations of this function.
+func (f *ContextSetParentFunc) History() []ContextSetParentFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextSetParentFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextSetParentFuncCall is an object that describes an invocation of
+// method SetParent on an instance of MockContext.
+type ContextSetParentFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 inject.Injector
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ContextSetCookieFuncCall) Args() []interface{} {
+func (c ContextSetParentFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextSetCookieFuncCall) Results() []interface{} {
+func (c ContextSetParentFuncCall) Results() []interface{} {
 	return []interface{}{}
 }
 
-// ContextSetParentFunc describes the behavior when the SetParent method of
+// ContextSetValueFunc describes the behavior when the SetValue method of
 // the parent MockContext instance is invoked.
-type ContextSetParentFunc struct {
-	defaultHook func(inject.Injector)
-	hooks       []func(inject.Injector)
-	history     []ContextSetParentFuncCall
+type ContextSetValueFunc struct {
+	defaultHook func(interface{}, interface{})
+	hooks       []func(interface{}, interface{})
+	history     []ContextSetValueFuncCall
 	mutex       sync.Mutex
 }
 
-// SetParent delegates to the next hook function in the queue and stores the
+// SetValue delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockContext) SetParent(v0 inject.Injector) {
-	m.SetParentFunc.nextHook()(v0)
-	m.SetParentFunc.appendCall(ContextSetParentFuncCall{v0})
+func (m *MockContext) SetValue(v0 interface{}, v1 interface{}) {
+	m.SetValueFunc.nextHook()(v0, v1)
+	m.SetValueFunc.appendCall(ContextSetValueFuncCall{v0, v1})
 	return
 }
 
-// SetDefaultHook sets function that is called when the SetParent method of
+// SetDefaultHook sets function that is called when the SetValue method of
 // the parent MockContext instance is invoked and the hook queue is empty.
-func (f *ContextSetParentFunc) SetDefaultHook(hook func(inject.Injector)) {
+func (f *ContextSetValueFunc) SetDefaultHook(hook func(interface{}, interface{})) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// SetParent method of the parent MockContext instance invokes the hook at
+// SetValue method of the parent MockContext instance invokes the hook at
 // the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *ContextSetParentFunc) PushHook(hook func(inject.Injector)) {
+func (f *ContextSetValueFunc) PushHook(hook func(interface{}, interface{})) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3012,20 +5374,20 @@ func (f *ContextSetParentFunc) PushHook(hook func(inject.Injector)) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ContextSetParentFunc) SetDefaultReturn() {
-	f.SetDefaultHook(func(inject.Injector) {
+func (f *ContextSetValueFunc) SetDefaultReturn() {
+	f.SetDefaultHook(func(interface{}, interface{}) {
 		return
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ContextSetParentFunc) PushReturn() {
-	f.PushHook(func(inject.Injector) {
+func (f *ContextSetValueFunc) PushReturn() {
+	f.PushHook(func(interface{}, interface{}) {
 		return
 	})
 }
 
-func (f *ContextSetParentFunc) nextHook() func(inject.Injector) {
+func (f *ContextSetValueFunc) nextHook() func(interface{}, interface{}) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3038,40 +5400,43 @@ func (f *ContextSetParentFunc) nextHook() func(inject.Injector) {
 	return hook
 }
 
-func (f *ContextSetParentFunc) appendCall(r0 ContextSetParentFuncCall) {
+func (f *ContextSetValueFunc) appendCall(r0 ContextSetValueFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ContextSetParentFuncCall objects describing
+// History returns a sequence of ContextSetValueFuncCall objects describing
 // the invocations of this function.
-func (f *ContextSetParentFunc) History() []ContextSetParentFuncCall {
+func (f *ContextSetValueFunc) History() []ContextSetValueFuncCall {
 	f.mutex.Lock()
-	history := make([]ContextSetParentFuncCall, len(f.history))
+	history := make([]ContextSetValueFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ContextSetParentFuncCall is an object that describes an invocation of
-// method SetParent on an instance of MockContext.
-type ContextSetParentFuncCall struct {
+// ContextSetValueFuncCall is an object that describes an invocation of
+// method SetValue on an instance of MockContext.
+type ContextSetValueFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 inject.Injector
+	Arg0 interface{}
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 interface{}
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ContextSetParentFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c ContextSetValueFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ContextSetParentFuncCall) Results() []interface{} {
+func (c ContextSetValueFuncCall) Results() []interface{} {
 	return []interface{}{}
 }
 
@@ -3186,6 +5551,107 @@ func (c ContextURLPathFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ContextUnmapFunc describes the behavior when the Unmap method of the
+// parent MockContext instance is invoked.
+type ContextUnmapFunc struct {
+	defaultHook func(reflect.Type) inject.Container
+	hooks       []func(reflect.Type) inject.Container
+	history     []ContextUnmapFuncCall
+	mutex       sync.Mutex
+}
+
+// Unmap delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Unmap(v0 reflect.Type) inject.Container {
+	r0 := m.UnmapFunc.nextHook()(v0)
+	m.UnmapFunc.appendCall(ContextUnmapFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Unmap method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextUnmapFunc) SetDefaultHook(hook func(reflect.Type) inject.Container) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Unmap method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextUnmapFunc) PushHook(hook func(reflect.Type) inject.Container) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextUnmapFunc) SetDefaultReturn(r0 inject.Container) {
+	f.SetDefaultHook(func(reflect.Type) inject.Container {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextUnmapFunc) PushReturn(r0 inject.Container) {
+	f.PushHook(func(reflect.Type) inject.Container {
+		return r0
+	})
+}
+
+func (f *ContextUnmapFunc) nextHook() func(reflect.Type) inject.Container {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextUnmapFunc) appendCall(r0 ContextUnmapFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextUnmapFuncCall objects describing the
+// invocations of this function.
+func (f *ContextUnmapFunc) History() []ContextUnmapFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextUnmapFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextUnmapFuncCall is an object that describes an invocation of method
+// Unmap on an instance of MockContext.
+type ContextUnmapFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 reflect.Type
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 inject.Container
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextUnmapFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextUnmapFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ContextValueFunc describes the behavior when the Value method of the
 // parent MockContext instance is invoked.
 type ContextValueFunc struct {
@@ -3286,3 +5752,200 @@ func (c ContextValueFuncCall) Args() []interface{} {
 func (c ContextValueFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// ContextValuesFunc describes the behavior when the Values method of the
+// parent MockContext instance is invoked.
+type ContextValuesFunc struct {
+	defaultHook func() map[interface{}]interface{}
+	hooks       []func() map[interface{}]interface{}
+	history     []ContextValuesFuncCall
+	mutex       sync.Mutex
+}
+
+// Values delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockContext) Values() map[interface{}]interface{} {
+	r0 := m.ValuesFunc.nextHook()()
+	m.ValuesFunc.appendCall(ContextValuesFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Values method of the
+// parent MockContext instance is invoked and the hook queue is empty.
+func (f *ContextValuesFunc) SetDefaultHook(hook func() map[interface{}]interface{}) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Values method of the parent MockContext instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ContextValuesFunc) PushHook(hook func() map[interface{}]interface{}) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextValuesFunc) SetDefaultReturn(r0 map[interface{}]interface{}) {
+	f.SetDefaultHook(func() map[interface{}]interface{} {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextValuesFunc) PushReturn(r0 map[interface{}]interface{}) {
+	f.PushHook(func() map[interface{}]interface{} {
+		return r0
+	})
+}
+
+func (f *ContextValuesFunc) nextHook() func() map[interface{}]interface{} {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextValuesFunc) appendCall(r0 ContextValuesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextValuesFuncCall objects describing
+// the invocations of this function.
+func (f *ContextValuesFunc) History() []ContextValuesFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextValuesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextValuesFuncCall is an object that describes an invocation of method
+// Values on an instance of MockContext.
+type ContextValuesFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[interface{}]interface{}
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextValuesFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextValuesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ContextWithContextFunc describes the behavior when the WithContext method
+// of the parent MockContext instance is invoked.
+type ContextWithContextFunc struct {
+	defaultHook func(gocontext.Context)
+	hooks       []func(gocontext.Context)
+	history     []ContextWithContextFuncCall
+	mutex       sync.Mutex
+}
+
+// WithContext delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockContext) WithContext(v0 gocontext.Context) {
+	m.WithContextFunc.nextHook()(v0)
+	m.WithContextFunc.appendCall(ContextWithContextFuncCall{v0})
+	return
+}
+
+// SetDefaultHook sets function that is called when the WithContext method
+// of the parent MockContext instance is invoked and the hook queue is
+// empty.
+func (f *ContextWithContextFunc) SetDefaultHook(hook func(gocontext.Context)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WithContext method of the parent MockContext instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ContextWithContextFunc) PushHook(hook func(gocontext.Context)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ContextWithContextFunc) SetDefaultReturn() {
+	f.SetDefaultHook(func(gocontext.Context) {
+		return
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ContextWithContextFunc) PushReturn() {
+	f.PushHook(func(gocontext.Context) {
+		return
+	})
+}
+
+func (f *ContextWithContextFunc) nextHook() func(gocontext.Context) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ContextWithContextFunc) appendCall(r0 ContextWithContextFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ContextWithContextFuncCall objects
+// describing the invocations of this function.
+func (f *ContextWithContextFunc) History() []ContextWithContextFuncCall {
+	f.mutex.Lock()
+	history := make([]ContextWithContextFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ContextWithContextFuncCall is an object that describes an invocation of
+// method WithContext on an instance of MockContext.
+type ContextWithContextFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 gocontext.Context
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ContextWithContextFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ContextWithContextFuncCall) Results() []interface{} {
+	return []interface{}{}
+}
@@ -9,13 +9,18 @@ var _ internalContext = (*mockContext)(nil)
 type mockContext struct {
 	*MockContext
 
-	setAction_ func(Handler)
-	run_       func()
+	setAction_           func(Handler)
+	setTrustedProxies_   func(trustedProxies)
+	setStatusHandlers_   func(map[int][]Handler)
+	setTraceOptions_     func(*TraceOptions)
+	setFallbackHandlers_ func(int)
+	run_                 func()
 }
 
 func newMockContext() *mockContext {
 	return &mockContext{
-		MockContext: NewMockContext(),
+		MockContext:          NewMockContext(),
+		setFallbackHandlers_: func(int) {},
 	}
 }
 
@@ -23,6 +28,22 @@ func (c *mockContext) setAction(h Handler) {
 	c.setAction_(h)
 }
 
+func (c *mockContext) setTrustedProxies(proxies trustedProxies) {
+	c.setTrustedProxies_(proxies)
+}
+
+func (c *mockContext) setStatusHandlers(handlers map[int][]Handler) {
+	c.setStatusHandlers_(handlers)
+}
+
+func (c *mockContext) setTraceOptions(opts *TraceOptions) {
+	c.setTraceOptions_(opts)
+}
+
+func (c *mockContext) setFallbackHandlers(n int) {
+	c.setFallbackHandlers_(n)
+}
+
 func (c *mockContext) run() {
 	c.run_()
 }
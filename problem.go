@@ -0,0 +1,250 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"charm.land/log/v2"
+)
+
+// Problem is a problem details object as defined in RFC 9457, which can be
+// returned from handlers, reported via Context.Error or rendered via
+// Render.Problem. It is responded in XML format ("application/problem+xml")
+// when the client prefers it via the "Accept" request header, and in JSON
+// format ("application/problem+json") otherwise.
+type Problem struct {
+	// Type is a URI reference that identifies the problem type. Default is
+	// "about:blank" when not set.
+	Type string
+	// Title is a short, human-readable summary of the problem type. Default is the
+	// status text of the Status when the Type is not set.
+	Title string
+	// Status is the HTTP status code of the response. Default is
+	// http.StatusInternalServerError when not set.
+	Status int
+	// Detail is a human-readable explanation specific to this occurrence of the
+	// problem.
+	Detail string
+	// Instance is a URI reference that identifies the specific occurrence of the
+	// problem.
+	Instance string
+	// Extensions contains additional members of the problem, which are encoded as
+	// if they were members of the problem itself.
+	Extensions map[string]interface{}
+}
+
+// NewProblem returns a new Problem with the given status code and detail.
+func NewProblem(status int, detail string) *Problem {
+	return &Problem{
+		Status: status,
+		Detail: detail,
+	}
+}
+
+func (p *Problem) Error() string {
+	p = p.withDefaults()
+	if p.Detail == "" {
+		return fmt.Sprintf("%d %s", p.Status, p.Title)
+	}
+	return fmt.Sprintf("%d %s: %s", p.Status, p.Title, p.Detail)
+}
+
+// StatusCode returns the HTTP status code of the problem.
+func (p *Problem) StatusCode() int {
+	return p.withDefaults().Status
+}
+
+// withDefaults returns a copy of the problem with defaults applied.
+func (p *Problem) withDefaults() *Problem {
+	cp := *p
+	if cp.Status == 0 {
+		cp.Status = http.StatusInternalServerError
+	}
+	if cp.Title == "" && (cp.Type == "" || cp.Type == "about:blank") {
+		cp.Title = http.StatusText(cp.Status)
+	}
+	return &cp
+}
+
+// members returns all members of the problem, where standard members take
+// precedence over extensions with the same names.
+func (p *Problem) members() map[string]interface{} {
+	members := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		members[k] = v
+	}
+	if p.Type != "" {
+		members["type"] = p.Type
+	}
+	if p.Title != "" {
+		members["title"] = p.Title
+	}
+	if p.Status != 0 {
+		members["status"] = p.Status
+	}
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	}
+	return members
+}
+
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.members())
+}
+
+// MarshalXML encodes the problem in the XML format as defined in Appendix B of
+// RFC 9457, where elements of arrays are encoded as "i" elements.
+func (p *Problem) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	members := p.members()
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	start := xml.StartElement{
+		Name: xml.Name{Local: "problem"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: "urn:ietf:rfc:7807"}},
+	}
+	err := e.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		err = encodeProblemMember(e, name, members[name])
+		if err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func encodeProblemMember(e *xml.Encoder, name string, v interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	rv := reflect.ValueOf(v)
+	if v == nil || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || isByteSlice(rv) {
+		return e.EncodeElement(v, start)
+	}
+
+	err := e.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < rv.Len(); i++ {
+		err = encodeProblemMember(e, "i", rv.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// prefersProblemXML returns true if the "Accept" request header prefers the XML
+// format of problem details over the JSON format. Media ranges are compared by
+// their q-values, and the one that comes first wins a tie.
+func prefersProblemXML(r *http.Request) bool {
+	xmlQ, xmlIndex := acceptQuality(r, "application/problem+xml")
+	if xmlIndex == -1 || xmlQ == 0 {
+		return false
+	}
+	jsonQ, jsonIndex := acceptQuality(r, "application/problem+json")
+	if jsonIndex == -1 || xmlQ != jsonQ {
+		return xmlQ > jsonQ
+	}
+	return xmlIndex < jsonIndex
+}
+
+// acceptQuality returns the q-value of the media type in the "Accept" request
+// headers and the index of its media range, or -1 for the index if the media
+// type is not present. The q-value is 1 when not specified, and 0 when invalid.
+func acceptQuality(r *http.Request, mediaType string) (q float64, index int) {
+	index = -1
+	i := 0
+	for _, header := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(header, ",") {
+			i++
+			typ, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil || typ != mediaType {
+				continue
+			}
+
+			q = 1
+			if v, ok := params["q"]; ok {
+				q, err = strconv.ParseFloat(v, 64)
+				if err != nil || q < 0 || q > 1 {
+					q = 0
+				}
+			}
+			return q, i - 1
+		}
+	}
+	return 0, -1
+}
+
+// writeProblem writes the problem to the response in the format that is
+// preferred by the request. Errors of encoding the problem are logged by the
+// logger if not nil, and the client gets the status text of
+// http.StatusInternalServerError.
+func writeProblem(w http.ResponseWriter, r *http.Request, logger *log.Logger, p *Problem) {
+	p = p.withDefaults()
+
+	var (
+		contentType string
+		body        []byte
+		err         error
+	)
+	if prefersProblemXML(r) {
+		contentType = "application/problem+xml"
+		body, err = xml.Marshal(p)
+		body = append([]byte(xml.Header), body...)
+	} else {
+		contentType = "application/problem+json"
+		body, err = json.Marshal(p)
+	}
+	if err != nil {
+		if logger != nil {
+			logger.Error("Failed to encode problem details", "error", err)
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(body)
+}
+
+// ProblemDetails makes the Flame instance to respond with problem details
+// objects as defined in RFC 9457 for the built-in http.StatusNotFound and
+// http.StatusMethodNotAllowed responses. It replaces handlers that are set by
+// NotFound and MethodNotAllowed. Use RecoveryOptions.ProblemDetails for
+// recovered panics.
+func (f *Flame) ProblemDetails() {
+	problemHandler := func(status int) Handler {
+		return ContextInvoker(func(c Context) {
+			writeFallback(c.ResponseWriter(), func() {
+				writeProblem(c.ResponseWriter(), c.Request().Request, contextLogger(c), &Problem{
+					Status:   status,
+					Instance: c.Request().URL.Path,
+				})
+			})
+		})
+	}
+	f.NotFound(problemHandler(http.StatusNotFound))
+	f.MethodNotAllowed(problemHandler(http.StatusMethodNotAllowed))
+}
@@ -0,0 +1,233 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProblem(t *testing.T) {
+	p := &Problem{
+		Type:     "https://example.com/probs/out-of-credit",
+		Title:    "You do not have enough credit.",
+		Status:   http.StatusForbidden,
+		Detail:   "Your current balance is 30, but that costs 50.",
+		Instance: "/account/12345/msgs/abc",
+		Extensions: map[string]interface{}{
+			"balance":  30,
+			"accounts": []string{"/account/12345", "/account/67890"},
+			"status":   "ignored",
+		},
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		got, err := json.Marshal(p)
+		require.NoError(t, err)
+
+		want := `{"accounts":["/account/12345","/account/67890"],"balance":30,"detail":"Your current balance is 30, but that costs 50.","instance":"/account/12345/msgs/abc","status":403,"title":"You do not have enough credit.","type":"https://example.com/probs/out-of-credit"}`
+		assert.Equal(t, want, string(got))
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		got, err := xml.Marshal(p)
+		require.NoError(t, err)
+
+		want := `<problem xmlns="urn:ietf:rfc:7807">` +
+			`<accounts><i>/account/12345</i><i>/account/67890</i></accounts>` +
+			`<balance>30</balance>` +
+			`<detail>Your current balance is 30, but that costs 50.</detail>` +
+			`<instance>/account/12345/msgs/abc</instance>` +
+			`<status>403</status>` +
+			`<title>You do not have enough credit.</title>` +
+			`<type>https://example.com/probs/out-of-credit</type>` +
+			`</problem>`
+		assert.Equal(t, want, string(got))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		assert.Equal(t, "403 You do not have enough credit.: Your current balance is 30, but that costs 50.", p.Error())
+		assert.Equal(t, "404 Not Found", (&Problem{Status: http.StatusNotFound}).Error())
+		assert.Equal(t, http.StatusInternalServerError, (&Problem{}).StatusCode())
+	})
+}
+
+func TestProblem_Response(t *testing.T) {
+	f := NewWithLogger(&bytes.Buffer{})
+	f.Use(Renderer())
+	f.Get("/returned", func() *Problem {
+		return NewProblem(http.StatusConflict, "already exists")
+	})
+	f.Get("/reported", func(c Context) {
+		c.Error(errors.Wrap(NewProblem(http.StatusNotFound, "no such user"), "find user"))
+	})
+	f.Get("/with-status", func() (int, error) {
+		return http.StatusBadRequest, NewProblem(http.StatusNotFound, "")
+	})
+	f.Get("/render", func(r Render) {
+		r.Problem(&Problem{Type: "https://example.com/probs/test", Status: http.StatusTeapot})
+	})
+
+	tests := []struct {
+		name            string
+		path            string
+		accept          string
+		wantCode        int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "returned",
+			path:            "/returned",
+			wantCode:        http.StatusConflict,
+			wantContentType: "application/problem+json",
+			wantBody:        `{"detail":"already exists","status":409,"title":"Conflict"}`,
+		},
+		{
+			name:            "returned in XML",
+			path:            "/returned",
+			accept:          "application/problem+xml, application/problem+json",
+			wantCode:        http.StatusConflict,
+			wantContentType: "application/problem+xml",
+			wantBody:        xml.Header + `<problem xmlns="urn:ietf:rfc:7807"><detail>already exists</detail><status>409</status><title>Conflict</title></problem>`,
+		},
+		{
+			name:            "reported",
+			path:            "/reported",
+			wantCode:        http.StatusNotFound,
+			wantContentType: "application/problem+json",
+			wantBody:        `{"detail":"no such user","status":404,"title":"Not Found"}`,
+		},
+		{
+			name:            "with status",
+			path:            "/with-status",
+			wantCode:        http.StatusBadRequest,
+			wantContentType: "application/problem+json",
+			wantBody:        `{"status":400,"title":"Not Found"}`,
+		},
+		{
+			name:            "render",
+			path:            "/render",
+			wantCode:        http.StatusTeapot,
+			wantContentType: "application/problem+json",
+			wantBody:        `{"status":418,"type":"https://example.com/probs/test"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, test.path, nil)
+			require.NoError(t, err)
+			req.Header.Set("Accept", test.accept)
+
+			f.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.wantCode, resp.Code)
+			assert.Equal(t, test.wantContentType, resp.Header().Get("Content-Type"))
+			assert.Equal(t, test.wantBody, resp.Body.String())
+		})
+	}
+}
+
+func TestProblem_EncodingError(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewWithLogger(&buf)
+	f.Get("/", func() *Problem {
+		return &Problem{
+			Status:     http.StatusConflict,
+			Extensions: map[string]interface{}{"secret": map[string]string{"key": "value"}},
+		}
+	})
+
+	resp := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/problem+xml")
+
+	f.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, "Internal Server Error\n", resp.Body.String())
+	assert.Contains(t, buf.String(), "Failed to encode problem details")
+}
+
+func TestPrefersProblemXML(t *testing.T) {
+	tests := []struct {
+		accept []string
+		want   bool
+	}{
+		{accept: nil, want: false},
+		{accept: []string{"application/problem+xml"}, want: true},
+		{accept: []string{"application/problem+xml, application/problem+json"}, want: true},
+		{accept: []string{"application/problem+json, application/problem+xml"}, want: false},
+		{accept: []string{"application/problem+json;q=0, application/problem+xml"}, want: true},
+		{accept: []string{"application/problem+json;q=0.5, application/problem+xml;q=0.8"}, want: true},
+		{accept: []string{"application/problem+xml;q=0.5, application/problem+json"}, want: false},
+		{accept: []string{"application/problem+xml;q=0"}, want: false},
+		{accept: []string{"application/problem+xml;q=invalid"}, want: false},
+		{accept: []string{"text/html", "application/problem+xml"}, want: true},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.accept, "; "), func(t *testing.T) {
+			r := &http.Request{Header: http.Header{"Accept": test.accept}}
+			assert.Equal(t, test.want, prefersProblemXML(r))
+		})
+	}
+}
+
+func TestFlame_ProblemDetails(t *testing.T) {
+	f := NewWithLogger(&bytes.Buffer{})
+	f.ProblemDetails()
+	f.Get("/users", func() {})
+	f.Post("/users", func() {})
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		wantCode  int
+		wantAllow string
+		wantBody  string
+	}{
+		{
+			name:     "not found",
+			method:   http.MethodGet,
+			path:     "/404",
+			wantCode: http.StatusNotFound,
+			wantBody: `{"instance":"/404","status":404,"title":"Not Found"}`,
+		},
+		{
+			name:      "method not allowed",
+			method:    http.MethodDelete,
+			path:      "/users",
+			wantCode:  http.StatusMethodNotAllowed,
+			wantAllow: "GET, POST",
+			wantBody:  `{"instance":"/users","status":405,"title":"Method Not Allowed"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req, err := http.NewRequest(test.method, test.path, nil)
+			require.NoError(t, err)
+
+			f.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.wantCode, resp.Code)
+			assert.Equal(t, "application/problem+json", resp.Header().Get("Content-Type"))
+			assert.Equal(t, test.wantAllow, resp.Header().Get("Allow"))
+			assert.Equal(t, test.wantBody, resp.Body.String())
+		})
+	}
+}
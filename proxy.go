@@ -0,0 +1,276 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// TrustedProxies sets the list of IP addresses or CIDRs (e.g. "10.0.0.0/8") of
+// trusted proxies, and panics if any of them is malformed. Once set, the
+// Context.RemoteAddr, Context.Scheme and Context.Host only respect forwarding
+// headers ("Forwarded" as defined in RFC 7239, "X-Forwarded-For",
+// "X-Forwarded-Proto" and "X-Forwarded-Host") that are added by trusted
+// proxies, by walking the chain of proxies from the right. Calling it without
+// arguments trusts no proxy at all.
+//
+// When it is never called, the Context.Scheme and Context.Host ignore all
+// forwarding headers, and the Context.RemoteAddr keeps the legacy behavior of
+// respecting the "X-Real-IP" and "X-Forwarded-For" headers from any client.
+func (f *Flame) TrustedProxies(proxies ...string) {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, proxy := range proxies {
+		var prefix netip.Prefix
+		if strings.Contains(proxy, "/") {
+			var err error
+			prefix, err = netip.ParsePrefix(proxy)
+			if err != nil {
+				panic(fmt.Sprintf("unable to parse trusted proxy %q: %v", proxy, err))
+			}
+		} else {
+			addr, err := netip.ParseAddr(proxy)
+			if err != nil {
+				panic(fmt.Sprintf("unable to parse trusted proxy %q: %v", proxy, err))
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	f.trustedProxies = prefixes
+}
+
+// trustedProxies is the list of CIDRs of trusted proxies. A nil list indicates
+// trusted proxies are not configured, whereas an empty list trusts no proxy.
+type trustedProxies []netip.Prefix
+
+// trusts returns true if the given address belongs to a trusted proxy.
+func (ps trustedProxies) trusts(addr string) bool {
+	ip, ok := parseNodeIP(addr)
+	if !ok {
+		return false
+	}
+	for _, p := range ps {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNodeIP parses the IP address from a node of forwarding headers, which
+// may come with a port and/or be wrapped by brackets (for IPv6), e.g.
+// "[2001:db8::1]:8080".
+func parseNodeIP(node string) (netip.Addr, bool) {
+	if addrPort, err := netip.ParseAddrPort(node); err == nil {
+		return addrPort.Addr().Unmap(), true
+	}
+	addr, err := netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(node, "["), "]"))
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+// stripPort returns the host portion of the address without the port.
+func stripPort(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// forwardedNode is a hop of forwarding headers.
+type forwardedNode struct {
+	For   string
+	Proto string
+	Host  string
+}
+
+// parseForwarded parses all values of the "Forwarded" header as defined in RFC
+// 7239 into a list of hops in the order of proxies.
+func parseForwarded(values []string) []forwardedNode {
+	var nodes []forwardedNode
+	for _, value := range values {
+		for _, element := range splitQuoted(value, ',') {
+			var node forwardedNode
+			for _, pair := range splitQuoted(element, ';') {
+				k, v, ok := strings.Cut(pair, "=")
+				if !ok {
+					continue
+				}
+				v = strings.TrimSpace(v)
+				if len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"' {
+					v = strings.ReplaceAll(v[1:len(v)-1], `\"`, `"`)
+				}
+				switch strings.ToLower(strings.TrimSpace(k)) {
+				case "for":
+					node.For = v
+				case "proto":
+					node.Proto = strings.ToLower(v)
+				case "host":
+					node.Host = v
+				}
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// splitQuoted splits the string by the separator that is not inside a quoted
+// string.
+func splitQuoted(s string, sep byte) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quoted {
+				i++
+			}
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// splitList splits all values of a comma-separated list header into a list of
+// trimmed and non-empty entries.
+func splitList(values []string) []string {
+	var list []string
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			v = strings.TrimSpace(v)
+			if v != "" {
+				list = append(list, v)
+			}
+		}
+	}
+	return list
+}
+
+// clientNode walks the chain of proxies from the right and returns the hop
+// that is added by the outermost trusted proxy, i.e. the hop that carries the
+// client information. It returns false when the direct peer is not a trusted
+// proxy or there are no forwarding headers.
+func (c *context) clientNode() (forwardedNode, bool) {
+	// Forwarding headers can be forged by any client when trusted proxies are not
+	// configured.
+	if c.trustedProxies == nil {
+		return forwardedNode{}, false
+	}
+	if !c.trustedProxies.trusts(stripPort(c.Request().RemoteAddr)) {
+		return forwardedNode{}, false
+	}
+
+	header := c.Request().Header
+	var nodes []forwardedNode
+	if values := header.Values("Forwarded"); len(values) > 0 {
+		nodes = parseForwarded(values)
+	} else if fors := splitList(header.Values("X-Forwarded-For")); len(fors) > 0 {
+		nodes = make([]forwardedNode, len(fors))
+		for i := range fors {
+			nodes[i].For = fors[i]
+		}
+
+		// Only the rightmost values are added by the direct peer.
+		protos := splitList(header.Values("X-Forwarded-Proto"))
+		hosts := splitList(header.Values("X-Forwarded-Host"))
+		last := &nodes[len(nodes)-1]
+		if len(protos) > 0 {
+			last.Proto = strings.ToLower(protos[len(protos)-1])
+		}
+		if len(hosts) > 0 {
+			last.Host = hosts[len(hosts)-1]
+		}
+	} else if realIP := header.Get("X-Real-IP"); realIP != "" {
+		nodes = []forwardedNode{{For: realIP}}
+	}
+	if len(nodes) == 0 {
+		return forwardedNode{}, false
+	}
+
+	// Hops in the chain are added by the proxy that is on the right of it, and
+	// the proto and host are inherited from the right if absent.
+	i := len(nodes) - 1
+	node := nodes[i]
+	for {
+		if !c.trustedProxies.trusts(node.For) || i == 0 {
+			break
+		}
+		i--
+		next := nodes[i]
+		if next.For == "" {
+			break
+		}
+		if next.Proto == "" {
+			next.Proto = node.Proto
+		}
+		if next.Host == "" {
+			next.Host = node.Host
+		}
+		node = next
+	}
+	return node, true
+}
+
+func (c *context) RemoteAddr() string {
+	if c.trustedProxies == nil {
+		addr := c.Request().Header.Get("X-Real-IP")
+		if addr != "" {
+			return addr
+		}
+
+		addr = c.Request().Header.Get("X-Forwarded-For")
+		if addr != "" {
+			addr, _, _ = strings.Cut(addr, ",")
+			return strings.TrimSpace(addr)
+		}
+		return stripPort(c.Request().RemoteAddr)
+	}
+
+	node, ok := c.clientNode()
+	if ok && node.For != "" {
+		if ip, ok := parseNodeIP(node.For); ok {
+			return ip.String()
+		}
+	}
+	return stripPort(c.Request().RemoteAddr)
+}
+
+func (c *context) Scheme() string {
+	node, ok := c.clientNode()
+	if ok && (node.Proto == "http" || node.Proto == "https") {
+		return node.Proto
+	}
+
+	if c.Request().TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+func (c *context) Host() string {
+	node, ok := c.clientNode()
+	if ok && node.Host != "" {
+		return node.Host
+	}
+	return c.Request().Host
+}
+
+func (c *context) BaseURL() string {
+	return c.Scheme() + "://" + c.Host()
+}
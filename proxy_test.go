@@ -0,0 +1,168 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"bytes"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlame_TrustedProxies(t *testing.T) {
+	tests := []struct {
+		name        string
+		proxies     []string
+		remoteAddr  string
+		header      http.Header
+		tls         bool
+		wantAddr    string
+		wantBaseURL string
+	}{
+		{
+			name:        "legacy remote address by default",
+			remoteAddr:  "10.0.0.1:2830",
+			header:      http.Header{"X-Forwarded-For": {"1.1.1.1, 10.0.0.2"}, "X-Forwarded-Proto": {"https"}},
+			wantAddr:    "1.1.1.1",
+			wantBaseURL: "http://example.com",
+		},
+		{
+			name:        "ignore forwarded scheme and host by default",
+			remoteAddr:  "1.1.1.1:2830",
+			header:      http.Header{"X-Forwarded-Host": {"evil.com"}, "X-Forwarded-Proto": {"https"}, "Forwarded": {"proto=https;host=evil.com"}},
+			wantAddr:    "1.1.1.1",
+			wantBaseURL: "http://example.com",
+		},
+		{
+			name:        "no forwarding headers",
+			proxies:     []string{"10.0.0.0/8"},
+			remoteAddr:  "10.0.0.1:2830",
+			wantAddr:    "10.0.0.1",
+			wantBaseURL: "http://example.com",
+		},
+		{
+			name:        "TLS",
+			proxies:     []string{"10.0.0.0/8"},
+			remoteAddr:  "10.0.0.1:2830",
+			tls:         true,
+			wantAddr:    "10.0.0.1",
+			wantBaseURL: "https://example.com",
+		},
+		{
+			name:       "untrusted peer",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "2.2.2.2:2830",
+			header: http.Header{
+				"X-Real-Ip":         {"1.1.1.1"},
+				"X-Forwarded-For":   {"1.1.1.1"},
+				"X-Forwarded-Proto": {"https"},
+				"X-Forwarded-Host":  {"evil.com"},
+			},
+			wantAddr:    "2.2.2.2",
+			wantBaseURL: "http://example.com",
+		},
+		{
+			name:       "trust no proxy",
+			proxies:    []string{},
+			remoteAddr: "10.0.0.1:2830",
+			header: http.Header{
+				"X-Forwarded-For":   {"1.1.1.1"},
+				"X-Forwarded-Proto": {"https"},
+			},
+			wantAddr:    "10.0.0.1",
+			wantBaseURL: "http://example.com",
+		},
+		{
+			name:       "X-Forwarded-For with spoofed entries",
+			proxies:    []string{"10.0.0.0/8", "192.168.0.1"},
+			remoteAddr: "10.0.0.1:2830",
+			header: http.Header{
+				"X-Forwarded-For":   {"6.6.6.6, 1.1.1.1", "192.168.0.1"},
+				"X-Forwarded-Proto": {"http, https"},
+				"X-Forwarded-Host":  {"evil.com, flamego.dev"},
+			},
+			wantAddr:    "1.1.1.1",
+			wantBaseURL: "https://flamego.dev",
+		},
+		{
+			name:       "Forwarded",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.1:2830",
+			header: http.Header{
+				"Forwarded": {
+					`for=6.6.6.6;proto=http;host=evil.com`,
+					`for="[2001:db8::1]:4711";proto=https;host="flamego.dev", for=10.0.0.2`,
+				},
+				"X-Forwarded-For": {"3.3.3.3"},
+			},
+			wantAddr:    "2001:db8::1",
+			wantBaseURL: "https://flamego.dev",
+		},
+		{
+			name:        "X-Real-IP",
+			proxies:     []string{"10.0.0.0/8"},
+			remoteAddr:  "10.0.0.1:2830",
+			header:      http.Header{"X-Real-Ip": {"1.1.1.1"}},
+			wantAddr:    "1.1.1.1",
+			wantBaseURL: "http://example.com",
+		},
+		{
+			name:        "all trusted",
+			proxies:     []string{"10.0.0.0/8"},
+			remoteAddr:  "10.0.0.1:2830",
+			header:      http.Header{"X-Forwarded-For": {"10.0.0.3, 10.0.0.2"}},
+			wantAddr:    "10.0.0.3",
+			wantBaseURL: "http://example.com",
+		},
+		{
+			name:        "unknown client",
+			proxies:     []string{"10.0.0.0/8"},
+			remoteAddr:  "10.0.0.1:2830",
+			header:      http.Header{"Forwarded": {"for=unknown;proto=https"}},
+			wantAddr:    "10.0.0.1",
+			wantBaseURL: "https://example.com",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := NewWithLogger(&bytes.Buffer{})
+			if test.proxies != nil {
+				f.TrustedProxies(test.proxies...)
+			}
+			f.Get("/", func(c Context) string {
+				return c.RemoteAddr() + " " + c.BaseURL()
+			})
+
+			resp := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+			assert.Nil(t, err)
+			req.RemoteAddr = test.remoteAddr
+			for k, v := range test.header {
+				req.Header[k] = v
+			}
+			if test.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+
+			f.ServeHTTP(resp, req)
+
+			got := strings.SplitN(resp.Body.String(), " ", 2)
+			assert.Equal(t, test.wantAddr, got[0])
+			assert.Equal(t, test.wantBaseURL, got[1])
+		})
+	}
+
+	t.Run("malformed", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		assert.PanicsWithValue(t,
+			`unable to parse trusted proxy "10.0.0.0/33": netip.ParsePrefix("10.0.0.0/33"): prefix length out of range`,
+			func() { f.TrustedProxies("10.0.0.0/33") },
+		)
+	})
+}
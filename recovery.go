@@ -21,12 +21,33 @@ type RecoveryOptions struct {
 	// PlainText indicates whether to respond with plain text instead of HTML when
 	// recovering from a panic in development mode.
 	PlainText bool
+	// ProblemDetails indicates whether to respond with a problem details object as
+	// defined in RFC 9457, which includes the panic message as the detail only in
+	// development mode. It takes precedence over the PlainText.
+	ProblemDetails bool
+	// ValueKeys is the list of keys in the per-request key-value store whose
+	// values are included as fields of the log of the panic. Values are not
+	// logged by default to avoid leaking sensitive data.
+	ValueKeys []string
+}
+
+// panicValueKey is the key of the panic value recovered by Recovery in the
+// per-request key-value store.
+type panicValueKey struct{}
+
+// PanicValue returns the panic value recovered by Recovery, and whether there
+// was a panic. It is useful in functions registered via Context.After.
+func PanicValue(c Context) (interface{}, bool) {
+	return c.GetValue(panicValueKey{})
 }
 
 // Recovery returns a middleware handler that recovers from any panics and
 // writes a 500 status code to the response if there was one. While in
 // development mode (EnvTypeDev), Recovery will also output the panic as HTML,
-// or as plain text when the PlainText option is enabled.
+// or as plain text when the PlainText option is enabled. Values of keys that
+// are allow-listed by the ValueKeys option in the per-request key-value store
+// are included in the log of the panic, and the panic value is available to
+// functions registered via Context.After through PanicValue.
 func Recovery(opts ...RecoveryOptions) Handler {
 	var opt RecoveryOptions
 	if len(opts) > 0 {
@@ -139,29 +160,41 @@ pre {
 		defer func() {
 			if err := recover(); err != nil {
 				stack := bytes.TrimRight(stack(3), "\n")
-				logger.Error(fmt.Sprintf("PANIC: %s\n%s", err, stack))
+				logger.Error(fmt.Sprintf("PANIC: %s\n%s", err, stack), valueFields(c, opt.ValueKeys)...)
+				c.SetValue(panicValueKey{}, err)
 
 				// Lookup the current ResponseWriter
 				val := c.Value(inject.InterfaceOf((*http.ResponseWriter)(nil)))
 				w := val.Interface().(http.ResponseWriter)
 
-				// Respond with panic message only in development mode
-				var body []byte
-				if Env() == EnvTypeDev {
-					if opt.PlainText {
-						w.Header().Set("Content-Type", "text/plain")
-						body = []byte(fmt.Sprintf("PANIC: %s\n%s", err, stack))
+				writeFallback(w, func() {
+					if opt.ProblemDetails {
+						p := &Problem{Status: http.StatusInternalServerError}
+						if Env() == EnvTypeDev {
+							p.Detail = fmt.Sprintf("PANIC: %s", err)
+						}
+						writeProblem(w, c.Request().Request, logger, p)
+						return
+					}
+
+					// Respond with panic message only in development mode
+					var body []byte
+					if Env() == EnvTypeDev {
+						if opt.PlainText {
+							w.Header().Set("Content-Type", "text/plain")
+							body = []byte(fmt.Sprintf("PANIC: %s\n%s", err, stack))
+						} else {
+							w.Header().Set("Content-Type", "text/html")
+							body = []byte(fmt.Sprintf(html, err, stack))
+						}
 					} else {
-						w.Header().Set("Content-Type", "text/html")
-						body = []byte(fmt.Sprintf(html, err, stack))
+						w.Header().Set("Content-Type", "text/plain")
+						body = []byte(http.StatusText(http.StatusInternalServerError))
 					}
-				} else {
-					w.Header().Set("Content-Type", "text/plain")
-					body = []byte(http.StatusText(http.StatusInternalServerError))
-				}
 
-				w.WriteHeader(http.StatusInternalServerError)
-				_, _ = w.Write(body)
+					w.WriteHeader(http.StatusInternalServerError)
+					_, _ = w.Write(body)
+				})
 			}
 		}()
 
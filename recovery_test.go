@@ -50,6 +50,67 @@ func TestRecovery(t *testing.T) {
 		assert.NotEqual(t, '\n', resp.Body.String()[resp.Body.Len()-1])
 	})
 
+	t.Run("recovery from panic with values", func(t *testing.T) {
+		var buf bytes.Buffer
+		f := NewWithLogger(&buf)
+		f.Use(Recovery(RecoveryOptions{ValueKeys: []string{"user_id"}}))
+		f.Use(func(c Context) {
+			c.SetValue("user_id", 1)
+			c.SetValue("token", "secret")
+		})
+		f.Use(func() { panic("here is a panic!") })
+		f.Get("/", func() {})
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		assert.Contains(t, buf.String(), "user_id=1")
+		assert.NotContains(t, buf.String(), "secret")
+	})
+
+	t.Run("panic value in after functions", func(t *testing.T) {
+		var got interface{}
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Use(func(c Context) {
+			c.After(func() {
+				got, _ = PanicValue(c)
+				assert.Equal(t, http.StatusInternalServerError, c.ResponseWriter().Status())
+			})
+		})
+		f.Use(Recovery())
+		f.Use(func() { panic("here is a panic!") })
+		f.Get("/", func() {})
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, "here is a panic!", got)
+	})
+
+	t.Run("recovery from panic as problem details", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Use(Recovery(RecoveryOptions{ProblemDetails: true}))
+		f.Use(func() { panic("here is a panic!") })
+		f.Get("/", func() {})
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		assert.Nil(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+		assert.Equal(t, "application/problem+json", resp.Header().Get("Content-Type"))
+		assert.Equal(t, `{"detail":"PANIC: here is a panic!","status":500,"title":"Internal Server Error"}`, resp.Body.String())
+	})
+
 	t.Run("recovery from panic in non-development mode", func(t *testing.T) {
 		SetEnv(EnvTypeProd)
 		defer SetEnv(EnvTypeDev)
@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"net/http"
+
+	"charm.land/log/v2"
 )
 
 // Render is a thin wrapper to render content to the ResponseWriter.
@@ -22,11 +24,17 @@ type Render interface {
 	Binary(status int, v []byte)
 	// PlainText writes string with given status code to the ResponseWriter.
 	PlainText(status int, s string)
+	// Problem writes the problem details object to the ResponseWriter with the
+	// status code of the problem, in XML format when the client prefers it via the
+	// "Accept" request header, and in JSON format otherwise.
+	Problem(p *Problem)
 }
 
 type render struct {
 	opts           RenderOptions
 	responseWriter ResponseWriter // The ResponseWriter to write rendered content.
+	request        *http.Request  // The request to negotiate the content format.
+	logger         *log.Logger    // The logger of errors, nil when not available.
 }
 
 // RenderOptions contains options for the flamego.Renderer middleware.
@@ -86,6 +94,10 @@ func (r *render) PlainText(status int, s string) {
 	_, _ = r.responseWriter.Write([]byte(s))
 }
 
+func (r *render) Problem(p *Problem) {
+	writeProblem(r.responseWriter, r.request, r.logger, p)
+}
+
 // Renderer returns a middleware handler that injects flamego.Render into the
 // request context, which is used for rendering content to the ResponseWriter.
 func Renderer(opts ...RenderOptions) Handler {
@@ -103,11 +115,13 @@ func Renderer(opts ...RenderOptions) Handler {
 
 	opt = parseRenderOptions(opt)
 
-	return ContextInvoker(func(c Context) {
+	return Provides(ContextInvoker(func(c Context) {
 		r := &render{
 			opts:           opt,
 			responseWriter: c.ResponseWriter(),
+			request:        c.Request().Request,
+			logger:         contextLogger(c),
 		}
 		c.MapTo(r, (*Render)(nil))
-	})
+	}), (*Render)(nil))
 }
@@ -8,6 +8,8 @@ import (
 	"bufio"
 	"net"
 	"net/http"
+	"slices"
+	"strconv"
 	"sync"
 	"sync/atomic"
 
@@ -44,6 +46,17 @@ type responseWriter struct {
 	size        int          // The written size of the response.
 	beforeFuncs []BeforeFunc // The list of functions to be called before written to the response.
 
+	headFallback  bool // Whether the HEAD request is handled by handlers of the GET method.
+	headPending   bool // Whether the status of the HEAD response is yet to be written.
+	headDiscarded int  // The size of the discarded response body of the HEAD request.
+
+	interceptStatus func(int) bool          // Reports whether the status should be intercepted for status handlers.
+	intercepted     bool                    // Whether the status of the response is intercepted and yet to be written.
+	writingFallback bool                    // Whether the fallback body is being written.
+	fallbackBody    []byte                  // The fallback body of the intercepted response.
+	fallbackHeader  map[string]headerChange // The changes of headers made when writing the fallback body.
+	defaultStatus   int                     // The status to be written when writing without a status.
+
 	writeHeaderOnce sync.Once
 }
 
@@ -65,25 +78,228 @@ func (w *responseWriter) callBefore() {
 }
 
 func (w *responseWriter) WriteHeader(s int) {
+	// The intercepted status is neither written nor allowed to be overwritten
+	// until it is committed or taken by status handlers, and writing the header
+	// is only done once after that.
+	if w.intercepted {
+		return
+	}
+	if !w.Written() && w.interceptStatus != nil && w.interceptStatus(s) {
+		w.intercepted = true
+		atomic.StoreInt32(&w.status, int32(s))
+		return
+	}
+
 	w.writeHeaderOnce.Do(func() {
 		if w.Written() {
 			return
 		}
+		w.writeStatus(s)
+	})
+}
+
+// writeStatus calls before functions and writes the status to the underlying
+// http.ResponseWriter.
+func (w *responseWriter) writeStatus(s int) {
+	w.callBefore()
 
-		w.callBefore()
+	// The response body of the HEAD request that falls back to handlers of the GET
+	// method is discarded, delay writing the status until the end of the request
+	// to be able to determine the "Content-Length".
+	if w.headFallback {
+		w.headPending = true
+	} else {
 		w.ResponseWriter.WriteHeader(s)
-		atomic.StoreInt32(&w.status, int32(s))
-	})
+	}
+	atomic.StoreInt32(&w.status, int32(s))
 }
 
-func (w *responseWriter) Write(b []byte) (size int, err error) {
-	if !w.Written() {
-		// The status will be StatusOK if WriteHeader has not been called yet.
+// writeDefaultHeader writes the default status if WriteHeader has not been
+// called yet.
+func (w *responseWriter) writeDefaultHeader() {
+	if w.Written() {
+		return
+	}
+
+	if w.defaultStatus != 0 {
+		w.WriteHeader(w.defaultStatus)
+	} else {
 		w.WriteHeader(http.StatusOK)
 	}
+}
+
+// commitIntercepted writes the intercepted status and the fallback body to the
+// underlying http.ResponseWriter.
+func (w *responseWriter) commitIntercepted() {
+	if !w.intercepted {
+		return
+	}
+	w.intercepted = false
+
+	w.writeStatus(w.Status())
+	if len(w.fallbackBody) > 0 {
+		_, _ = w.write(w.fallbackBody)
+		w.fallbackBody = nil
+	}
+}
+
+// takeIntercepted returns the intercepted status and resets the ResponseWriter
+// to be unwritten with the interception disabled, which allows status handlers
+// to write the response. Headers that are set when writing the fallback body
+// are reverted until restoreIntercepted is called.
+func (w *responseWriter) takeIntercepted() (int, bool) {
+	if !w.intercepted {
+		return 0, false
+	}
+
+	status := w.Status()
+	w.intercepted = false
+	w.interceptStatus = nil
+	w.defaultStatus = status
+	atomic.StoreInt32(&w.status, 0)
+
+	for key, change := range w.fallbackHeader {
+		setHeaderValues(w.Header(), key, change.before)
+	}
+	return status, true
+}
+
+// restoreIntercepted writes the intercepted status and the fallback body when
+// status handlers did not write the response.
+func (w *responseWriter) restoreIntercepted(status int) {
+	if w.Written() {
+		return
+	}
+
+	for key, change := range w.fallbackHeader {
+		setHeaderValues(w.Header(), key, change.after)
+	}
+	w.WriteHeader(status)
+	if len(w.fallbackBody) > 0 {
+		_, _ = w.write(w.fallbackBody)
+		w.fallbackBody = nil
+	}
+}
+
+// writeFallback calls the function that writes the fallback body, e.g. the
+// built-in 404 page, to the ResponseWriter. The fallback body is replaced by
+// the status handler (see Flame.StatusHandler) of the response status if any.
+func writeFallback(w http.ResponseWriter, write func()) {
+	rw, ok := w.(*responseWriter)
+	if !ok {
+		write()
+		return
+	}
+
+	rw.writeFallback(true, write)
+}
+
+// headerChange is the change of a header made when writing the fallback body,
+// nil values indicate the header is absent.
+type headerChange struct {
+	before []string
+	after  []string
+}
+
+// writeFallback calls the function and treats its writes as the fallback body
+// if `fallback` is true, recording changes of headers made by the function.
+func (w *responseWriter) writeFallback(fallback bool, write func()) {
+	writing := w.writingFallback
+	w.writingFallback = fallback
+	defer func() { w.writingFallback = writing }()
+
+	if !fallback {
+		write()
+		return
+	}
+
+	before := w.Header().Clone()
+	defer func() {
+		after := w.Header()
+		for key, v := range after {
+			if !slices.Equal(before[key], v) {
+				w.recordFallbackHeader(key, before[key], v)
+			}
+		}
+		for key, v := range before {
+			if _, ok := after[key]; !ok {
+				w.recordFallbackHeader(key, v, nil)
+			}
+		}
+	}()
+	write()
+}
+
+// recordFallbackHeader records the change of the header, keeping the earliest
+// value before the change.
+func (w *responseWriter) recordFallbackHeader(key string, before, after []string) {
+	if w.fallbackHeader == nil {
+		w.fallbackHeader = make(map[string]headerChange)
+	}
+	if change, ok := w.fallbackHeader[key]; ok {
+		before = change.before
+	}
+	w.fallbackHeader[key] = headerChange{
+		before: before,
+		after:  slices.Clone(after),
+	}
+}
+
+// setHeaderValues sets values of the header, or deletes the header when values
+// are nil.
+func setHeaderValues(h http.Header, key string, values []string) {
+	if values == nil {
+		delete(h, key)
+	} else {
+		h[key] = slices.Clone(values)
+	}
+}
+
+// writeHeadStatus writes the delayed status of the HEAD response to the
+// underlying http.ResponseWriter. When `withLength` is true and any response
+// body was discarded, the "Content-Length" is set to the size of the discarded
+// response body unless it is already set.
+func (w *responseWriter) writeHeadStatus(withLength bool) {
+	if !w.headPending {
+		return
+	}
+	w.headPending = false
+
+	status := w.Status()
+	if withLength &&
+		w.headDiscarded > 0 &&
+		status >= http.StatusOK &&
+		status != http.StatusNoContent &&
+		status != http.StatusNotModified &&
+		w.Header().Get("Content-Length") == "" &&
+		w.Header().Get("Transfer-Encoding") == "" {
+		w.Header().Set("Content-Length", strconv.Itoa(w.headDiscarded))
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (size int, err error) {
+	// The status will be StatusOK if WriteHeader has not been called yet.
+	w.writeDefaultHeader()
+
+	if w.intercepted {
+		if w.writingFallback {
+			w.fallbackBody = append(w.fallbackBody, b...)
+			return len(b), nil
+		}
+		w.commitIntercepted()
+	}
+	return w.write(b)
+}
+
+// write writes the data to the underlying http.ResponseWriter, or discards it
+// for the HEAD request.
+func (w *responseWriter) write(b []byte) (size int, err error) {
 	if w.method != http.MethodHead {
 		size, err = w.ResponseWriter.Write(b)
 		w.size += size
+	} else {
+		w.headDiscarded += len(b)
 	}
 	return size, err
 }
@@ -113,10 +329,13 @@ func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 }
 
 func (w *responseWriter) Flush() {
-	if !w.Written() {
-		// The status will be StatusOK if WriteHeader has not been called yet.
-		w.WriteHeader(http.StatusOK)
-	}
+	// The status will be StatusOK if WriteHeader has not been called yet.
+	w.writeDefaultHeader()
+	w.commitIntercepted()
+
+	// The response body is streamed, it is impossible to determine the
+	// "Content-Length" of the HEAD response.
+	w.writeHeadStatus(false)
 
 	flusher, ok := w.ResponseWriter.(http.Flusher)
 	if ok {
@@ -82,6 +82,61 @@ func TestResponseWriter(t *testing.T) {
 
 		assert.Equal(t, "barfoo", buf.String())
 	})
+
+	t.Run("head request", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		w := NewResponseWriter(http.MethodHead, resp)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("Hello world"))
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Empty(t, resp.Body.String())
+		assert.Empty(t, resp.Header().Get("Content-Length"))
+	})
+
+	t.Run("head request falls back to GET", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		w := NewResponseWriter(http.MethodHead, resp)
+		w.(*responseWriter).headFallback = true
+
+		_, _ = w.Write([]byte("Hello world"))
+		_, _ = w.Write([]byte("foo bar bat baz"))
+		assert.True(t, w.Written())
+		assert.False(t, resp.Flushed)
+
+		w.(*responseWriter).writeHeadStatus(true)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Empty(t, resp.Body.String())
+		assert.Equal(t, "26", resp.Header().Get("Content-Length"))
+		assert.Equal(t, 0, w.Size())
+	})
+
+	t.Run("head request with streaming", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		w := NewResponseWriter(http.MethodHead, resp)
+		w.(*responseWriter).headFallback = true
+
+		_, _ = w.Write([]byte("Hello world"))
+		w.Flush()
+		_, _ = w.Write([]byte("foo bar bat baz"))
+		w.(*responseWriter).writeHeadStatus(true)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Empty(t, resp.Header().Get("Content-Length"))
+	})
+
+	t.Run("head request falls back to GET without body", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		w := NewResponseWriter(http.MethodHead, resp)
+		w.(*responseWriter).headFallback = true
+
+		w.WriteHeader(http.StatusOK)
+		w.(*responseWriter).writeHeadStatus(true)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Empty(t, resp.Header().Get("Content-Length"))
+	})
 }
 
 type hijackableResponse struct {
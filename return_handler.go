@@ -6,9 +6,9 @@ package flamego
 
 import (
 	"fmt"
-	"net/http"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/flamego/flamego/inject"
 )
@@ -29,10 +29,39 @@ var contextType = inject.InterfaceOf((*Context)(nil))
 
 type returnHandlers struct {
 	handlers []typedReturnHandler
+	matched  sync.Map // The cache of matched handlers, keys are returnKey
+}
+
+// maxCachedReturns is the maximum number of return values whose matched
+// handlers are cached.
+const maxCachedReturns = 3
+
+// returnKey is the key of return values for caching matched handlers.
+type returnKey struct {
+	n     int
+	types [maxCachedReturns]reflect.Type
+}
+
+// newReturnKey returns the key of return values, and false if the return values
+// are not cacheable.
+func newReturnKey(vals []reflect.Value) (returnKey, bool) {
+	if len(vals) > maxCachedReturns {
+		return returnKey{}, false
+	}
+
+	key := returnKey{n: len(vals)}
+	for i, val := range vals {
+		if !val.IsValid() {
+			return returnKey{}, false
+		}
+		key.types[i] = val.Type()
+	}
+	return key, true
 }
 
 type typedReturnHandler struct {
 	handler     TypedReturnHandler
+	fn          reflect.Value // The reflect.Value of the handler
 	argTypes    []reflect.Type
 	returnTypes []reflect.Type
 }
@@ -48,6 +77,12 @@ func newReturnHandlers() *returnHandlers {
 	hs.Register(func(c Context, err error) {
 		writeReturnValue(c, reflect.ValueOf(err))
 	})
+	hs.Register(func(c Context, p *Problem) {
+		if p == nil {
+			return
+		}
+		writeProblem(c.ResponseWriter(), c.Request().Request, contextLogger(c), p)
+	})
 	hs.Register(func(c Context, status int, body string) {
 		c.ResponseWriter().WriteHeader(status)
 		writeReturnValue(c, reflect.ValueOf(body))
@@ -57,8 +92,11 @@ func newReturnHandlers() *returnHandlers {
 		writeReturnValue(c, reflect.ValueOf(body))
 	})
 	hs.Register(func(c Context, status int, err error) {
-		c.ResponseWriter().WriteHeader(status)
-		writeReturnValue(c, reflect.ValueOf(err))
+		if err == nil {
+			c.ResponseWriter().WriteHeader(status)
+			return
+		}
+		c.Error(&statusError{status: status, err: err})
 	})
 	hs.Register(func(c Context, body string, err error) {
 		if err != nil {
@@ -80,6 +118,7 @@ func newReturnHandlers() *returnHandlers {
 func (hs *returnHandlers) Register(handler TypedReturnHandler) {
 	typedHandler := newTypedReturnHandler(handler)
 
+	defer hs.matched.Clear()
 	for i, h := range hs.handlers {
 		if sameTypes(h.returnTypes, typedHandler.returnTypes) {
 			hs.handlers[i] = typedHandler
@@ -90,17 +129,20 @@ func (hs *returnHandlers) Register(handler TypedReturnHandler) {
 }
 
 func (hs *returnHandlers) Handle(c Context, vals []reflect.Value) {
-	for _, h := range hs.handlers {
-		if h.matches(vals, false) {
-			h.invoke(c, vals)
+	key, cacheable := newReturnKey(vals)
+	if cacheable {
+		if h, ok := hs.matched.Load(key); ok {
+			h.(*typedReturnHandler).invoke(c, vals)
 			return
 		}
 	}
-	for _, h := range hs.handlers {
-		if h.matches(vals, true) {
-			h.invoke(c, vals)
-			return
+
+	if h := hs.match(vals); h != nil {
+		if cacheable {
+			hs.matched.Store(key, h)
 		}
+		h.invoke(c, vals)
+		return
 	}
 
 	types := make([]reflect.Type, len(vals))
@@ -112,6 +154,22 @@ func (hs *returnHandlers) Handle(c Context, vals []reflect.Value) {
 	panic(fmt.Sprintf("no return handler registered for return values (%s)", formatTypes(types)))
 }
 
+// match returns the handler that matches the return values by exact type
+// first, then by assignability in registration order, or nil if none.
+func (hs *returnHandlers) match(vals []reflect.Value) *typedReturnHandler {
+	for i := range hs.handlers {
+		if hs.handlers[i].matches(vals, false) {
+			return &hs.handlers[i]
+		}
+	}
+	for i := range hs.handlers {
+		if hs.handlers[i].matches(vals, true) {
+			return &hs.handlers[i]
+		}
+	}
+	return nil
+}
+
 func newTypedReturnHandler(handler TypedReturnHandler) typedReturnHandler {
 	if handler == nil {
 		panic("return handler must be a callable function, but got nil")
@@ -130,6 +188,7 @@ func newTypedReturnHandler(handler TypedReturnHandler) typedReturnHandler {
 
 	h := typedReturnHandler{
 		handler:  handler,
+		fn:       reflect.ValueOf(handler),
 		argTypes: make([]reflect.Type, 0, t.NumIn()),
 	}
 	for i := 0; i < t.NumIn(); i++ {
@@ -169,19 +228,19 @@ func (h typedReturnHandler) matches(vals []reflect.Value, assignable bool) bool
 	return true
 }
 
-func (h typedReturnHandler) invoke(c Context, vals []reflect.Value) {
-	args := make([]reflect.Value, 0, len(h.argTypes))
-	returnIndex := 0
-	for i := range h.argTypes {
-		if i == 0 {
-			args = append(args, reflect.ValueOf(c))
-			continue
-		}
-
-		args = append(args, vals[returnIndex])
-		returnIndex++
+func (h *typedReturnHandler) invoke(c Context, vals []reflect.Value) {
+	// Buffer arguments on the stack to avoid allocations for common cases.
+	var buf [maxCachedReturns + 1]reflect.Value
+	args := buf[:0]
+	if len(h.argTypes) > len(buf) {
+		args = make([]reflect.Value, 0, len(h.argTypes))
 	}
-	reflect.ValueOf(h.handler).Call(args)
+
+	// Pass the value of the interface type, which avoids checking whether the
+	// concrete type implements the Context on every call.
+	args = append(args, reflect.ValueOf(&c).Elem())
+	args = append(args, vals...)
+	h.fn.Call(args)
 }
 
 func sameTypes(a, b []reflect.Type) bool {
@@ -231,8 +290,7 @@ func writeReturnValue(c Context, respVal reflect.Value) {
 
 	w := c.ResponseWriter()
 	if err, ok := respVal.Interface().(error); ok && err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		_, _ = w.Write([]byte(err.Error()))
+		c.Error(err)
 		return
 	}
 
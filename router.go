@@ -7,17 +7,19 @@ package flamego
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/flamego/flamego/internal/route"
 )
 
 // Router is the router for adding routes and their handlers.
 type Router interface {
-	// AutoHead sets a boolean value which determines whether to add HEAD method
-	// automatically when GET method is added. Only routes that are added after call
-	// of this method will be affected, existing routes remain unchanged.
+	// AutoHead sets a boolean value which determines whether to fall back to
+	// handlers of the GET method for HEAD requests that have no matching route of
+	// the HEAD method. All routes are affected regardless of when they are added.
 	AutoHead(v bool)
 	// HandlerWrapper sets handlerWrapper for the router. It is used to wrap Handler
 	// and inject logic, and is especially useful for wrapping the Handler to
@@ -62,9 +64,30 @@ type Router interface {
 	// found. When it is not set, http.NotFound is used. Be sure to set
 	// http.StatusNotFound as the response status code in your last handler.
 	NotFound(handlers ...Handler)
+	// MethodNotAllowed configures handlers to be called when no matching route is
+	// found for the request method but for other methods, with the "Allow" header
+	// set to the list of allowed methods. When it is not set, the NotFound handlers
+	// are used. Be sure to set http.StatusMethodNotAllowed as the response status
+	// code in your last handler.
+	MethodNotAllowed(handlers ...Handler)
 	// URLPath builds the "path" portion of URL with given pairs of values. To
 	// include the optional segment, pass `"withOptional", "true"`.
 	URLPath(name string, pairs ...string) string
+	// URL returns a URLBuilder for building the URL of the named route. Unlike
+	// URLPath, errors are returned by the URLBuilder instead of panicking.
+	URL(name string) *URLBuilder
+	// BaseURL sets the base URL (e.g. "https://flamego.dev") that is used to build
+	// absolute URLs by URLBuilder.Absolute. It panics if the base URL is invalid.
+	BaseURL(baseURL string)
+	// SigningKey sets the secret key that is used to sign and verify URLs by
+	// SignedURLPath and Route.RequireSignature.
+	SigningKey(key []byte)
+	// SignedURLPath builds the escaped "path" portion of URL with the same
+	// arguments as URLPath, and appends the "expires" and "signature" query
+	// parameters. The URL never expires when the `expiry` is non-positive. It
+	// panics if the signing key is not set or values do not satisfy bind
+	// parameters of the route.
+	SignedURLPath(name string, expiry time.Duration, pairs ...string) string
 	// ServeHTTP implements the method of http.Handler.
 	ServeHTTP(w http.ResponseWriter, req *http.Request)
 }
@@ -73,13 +96,20 @@ type contextCreator func(http.ResponseWriter, *http.Request, route.Params, []Han
 
 type router struct {
 	parser       *route.Parser                    // The route parser.
-	autoHead     bool                             // Whether to automatically attach the same handler of a GET method as HEAD.
+	autoHead     bool                             // Whether to fall back to the GET method for unmatched HEAD requests.
 	groups       []group                          // The living stack of nested route groups.
 	routeTrees   map[string]route.Tree            // A set of route trees, keys are HTTP methods.
 	namedRoutes  map[string]route.Leaf            // A set of named routes.
 	staticRoutes map[string]map[string]route.Leaf // A set of static routes, keys are HTTP methods and full route paths.
+	signingKey   []byte                           // The secret key to sign and verify URLs.
+	baseURL      *url.URL                         // The base URL to build absolute URLs.
 
-	notFound http.HandlerFunc // The handler to be called when a route has no match.
+	notFound         http.HandlerFunc // The handler to be called when a route has no match.
+	methodNotAllowed http.HandlerFunc // The handler to be called when a route only has match for other methods.
+
+	routes                   []*Route  // The list of routes in the order of being added.
+	notFoundHandlers         []Handler // The list of handlers of the notFound.
+	methodNotAllowedHandlers []Handler // The list of handlers of the methodNotAllowed.
 
 	// contextCreator is used to create new Context for incoming requests.
 	contextCreator contextCreator
@@ -135,9 +165,31 @@ func (r *router) HandlerWrapper(f func(Handler) Handler) {
 
 // Route is a wrapper of the route leaves and its router.
 type Route struct {
-	router     *router
-	leaves     map[string]route.Leaf
-	predicates []route.Predicate // The list of predicates accumulated across Match calls.
+	router        *router
+	leaves        map[string]route.Leaf
+	headerMatcher *route.HeaderMatcher // The matcher for header values set by Headers call.
+	predicates    []route.Predicate    // The list of predicates accumulated across Match calls.
+
+	method          string                  // The HTTP method that the route was added with.
+	path            string                  // The full path of the route, including the path of groups.
+	groupPath       string                  // The path of groups that the route was added within.
+	handlers        []Handler               // The list of handlers of the route, including handlers of groups.
+	handler         route.Handler           // The handler that is bound to leaves of the route.
+	aliases         []map[string]route.Leaf // The list of leaves of aliases, keys are HTTP methods.
+	redirectAliases bool                    // Whether to redirect requests of aliases to the canonical path.
+}
+
+// eachLeaf calls the fn for every leaf of the route, including leaves of
+// aliases.
+func (r *Route) eachLeaf(fn func(method string, leaf route.Leaf)) {
+	for m, leaf := range r.leaves {
+		fn(m, leaf)
+	}
+	for _, leaves := range r.aliases {
+		for m, leaf := range leaves {
+			fn(m, leaf)
+		}
+	}
 }
 
 // Headers uses given key-value pairs as the list of matching criteria for
@@ -163,14 +215,15 @@ func (r *Route) Headers(pairs ...string) *Route {
 	for i := 1; i < len(pairs); i += 2 {
 		matches[pairs[i-1]] = regexp.MustCompile(pairs[i])
 	}
-	for m, leaf := range r.leaves {
-		leaf.SetHeaderMatcher(route.NewHeaderMatcher(matches))
+	r.headerMatcher = route.NewHeaderMatcher(matches)
+	r.eachLeaf(func(m string, leaf route.Leaf) {
+		leaf.SetHeaderMatcher(r.headerMatcher)
 
 		// Delete static route from fast paths since header matches are dynamic.
 		if leaf.Static() {
 			delete(r.router.staticRoutes[m], leaf.Route())
 		}
-	}
+	})
 	return r
 }
 
@@ -203,17 +256,95 @@ func (r *Route) Match(fn func(*http.Request) bool) *Route {
 
 	r.predicates = append(r.predicates, fn)
 	matcher := route.NewPredicateMatcher(r.predicates)
-	for m, leaf := range r.leaves {
+	r.eachLeaf(func(m string, leaf route.Leaf) {
 		leaf.SetPredicateMatcher(matcher)
 
 		// Delete static route from fast paths since predicate matches are dynamic.
 		if leaf.Static() {
 			delete(r.router.staticRoutes[m], leaf.Route())
 		}
+	})
+	return r
+}
+
+// Alias adds given route paths as aliases of the route, which share the same
+// handlers and HTTP methods with the route. Aliases inherit matching criteria
+// set by Headers and Match, and are never used by URLPath, which always builds
+// the canonical route path. Aliases added within a group are prefixed with the
+// group path in the same way as the route.
+//
+// For example:
+//
+//	f.Get("/users/{name}", h).
+//	    Alias("/u/{name}", "/people/{name}").
+//	    Name("user")
+func (r *Route) Alias(routePaths ...string) *Route {
+	for _, routePath := range routePaths {
+		leaves := make(map[string]route.Leaf, len(r.leaves))
+		for m := range r.leaves {
+			canonical := r.leaves[m]
+			alias := r.router.addRoute(m, r.groupPath+routePath, func(w http.ResponseWriter, req *http.Request, params route.Params) {
+				if r.redirectAliases {
+					if target, ok := canonicalPath(canonical, params); ok {
+						if req.URL.RawQuery != "" {
+							target += "?" + req.URL.RawQuery
+						}
+						http.Redirect(w, req, target, http.StatusMovedPermanently)
+						return
+					}
+				}
+				r.handler(w, req, params)
+			})
+
+			leaf := alias.leaves[m]
+			if r.headerMatcher != nil {
+				leaf.SetHeaderMatcher(r.headerMatcher)
+			}
+			if len(r.predicates) > 0 {
+				leaf.SetPredicateMatcher(route.NewPredicateMatcher(r.predicates))
+			}
+			if (r.headerMatcher != nil || len(r.predicates) > 0) && leaf.Static() {
+				delete(r.router.staticRoutes[m], leaf.Route())
+			}
+			leaves[m] = leaf
+		}
+		r.aliases = append(r.aliases, leaves)
 	}
 	return r
 }
 
+// RedirectAliases makes requests to aliases of the route to be redirected to
+// the canonical route path with http.StatusMovedPermanently, instead of being
+// handled by handlers of the route. Values of bind parameters and the query
+// string are carried over to the canonical path. Requests are still handled by
+// handlers of the route when values do not satisfy bind parameters of the
+// canonical route path.
+func (r *Route) RedirectAliases() *Route {
+	r.redirectAliases = true
+	return r
+}
+
+// canonicalPath builds the escaped path of the canonical leaf with values of
+// bind parameters extracted from an alias. The optional segment is only
+// included when values of all its bind parameters are available. It returns
+// false if values do not satisfy bind parameters of the canonical leaf.
+func canonicalPath(leaf route.Leaf, params route.Params) (string, bool) {
+	for _, withOptional := range []bool{true, false} {
+		vals := make(map[string]string)
+		for _, bind := range leaf.Binds(withOptional) {
+			if v, ok := params[bind]; ok {
+				vals[bind] = v
+			}
+		}
+
+		path, err := leaf.BuildURLPath(vals, withOptional)
+		if err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
 // Name sets the name for the route.
 func (r *Route) Name(name string) {
 	if name == "" {
@@ -277,8 +408,8 @@ type group struct {
 }
 
 func (r *router) Route(method, routePath string, handlers []Handler) *Route {
+	groupPath := ""
 	if len(r.groups) > 0 {
-		groupPath := ""
 		hs := make([]Handler, 0)
 		for _, g := range r.groups {
 			groupPath += g.path
@@ -290,9 +421,18 @@ func (r *router) Route(method, routePath string, handlers []Handler) *Route {
 	}
 
 	validateAndWrapHandlers(handlers, r.handlerWrapper)
-	return r.addRoute(method, routePath, func(w http.ResponseWriter, req *http.Request, params route.Params) {
-		r.contextCreator(w, req, params, handlers, r.URLPath).run()
-	})
+	var rt *Route
+	handler := func(w http.ResponseWriter, req *http.Request, params route.Params) {
+		r.contextCreator(w, req, params, rt.handlers, r.URLPath).run()
+	}
+	rt = r.addRoute(method, routePath, handler)
+	rt.method = method
+	rt.path = routePath
+	rt.groupPath = groupPath
+	rt.handlers = handlers
+	rt.handler = handler
+	r.routes = append(r.routes, rt)
+	return rt
 }
 
 func (r *router) Group(routePath string, fn func(), handlers ...Handler) {
@@ -307,11 +447,7 @@ func (r *router) Group(routePath string, fn func(), handlers ...Handler) {
 }
 
 func (r *router) Get(routePath string, handlers ...Handler) *Route {
-	route := r.Route(http.MethodGet, routePath, handlers)
-	if r.autoHead {
-		r.Head(routePath, handlers...)
-	}
-	return route
+	return r.Route(http.MethodGet, routePath, handlers)
 }
 
 func (r *router) Patch(routePath string, handlers ...Handler) *Route {
@@ -379,36 +515,83 @@ func (r *router) Routes(routePath, methods string, handlers ...Handler) *Route {
 
 func (r *router) NotFound(handlers ...Handler) {
 	validateAndWrapHandlers(handlers, r.handlerWrapper)
+	r.notFoundHandlers = handlers
 	r.notFound = func(w http.ResponseWriter, req *http.Request) {
+		c := r.contextCreator(w, req, nil, handlers, r.URLPath)
+		// Responses of NotFound handlers are replaced by status handlers, see
+		// Flame.StatusHandler.
+		c.setFallbackHandlers(len(handlers))
+		c.run()
+	}
+}
+
+func (r *router) MethodNotAllowed(handlers ...Handler) {
+	validateAndWrapHandlers(handlers, r.handlerWrapper)
+	r.methodNotAllowedHandlers = handlers
+	r.methodNotAllowed = func(w http.ResponseWriter, req *http.Request) {
 		r.contextCreator(w, req, nil, handlers, r.URLPath).run()
 	}
 }
 
-func (r *router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+// allowedMethods returns the list of HTTP methods that have matching routes for
+// the request.
+func (r *router) allowedMethods(req *http.Request) []string {
+	var methods []string
+	for _, m := range httpMethods {
+		if _, _, ok := r.match(m, req); ok {
+			methods = append(methods, m)
+		} else if m == http.MethodHead && r.autoHead {
+			if _, _, ok = r.match(http.MethodGet, req); ok {
+				methods = append(methods, m)
+			}
+		}
+	}
+	return methods
+}
+
+// match returns the leaf that matches the request in the route tree of the
+// given method, along with values of bind parameters.
+func (r *router) match(method string, req *http.Request) (route.Leaf, route.Params, bool) {
 	// Fast path for static routes
-	leaf, ok := r.staticRoutes[req.Method][req.URL.Path]
+	leaf, ok := r.staticRoutes[method][req.URL.Path]
 	if ok {
-		req.Pattern = req.Method + " " + leaf.Route()
-		leaf.Handler()(w, req, route.Params{
-			"route": leaf.Route(),
-		})
-		return
+		return leaf, route.Params{"route": leaf.Route()}, true
 	}
 
-	routeTree, ok := r.routeTrees[req.Method]
+	routeTree, ok := r.routeTrees[method]
 	if !ok {
-		r.notFound(w, req)
-		return
+		return nil, nil, false
 	}
 
 	leaf, params, ok := routeTree.Match(req.URL.Path, req)
 	if !ok {
+		return nil, nil, false
+	}
+
+	params["route"] = leaf.Route()
+	return leaf, params, true
+}
+
+func (r *router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	method := req.Method
+	leaf, params, ok := r.match(method, req)
+	if !ok && method == http.MethodHead && r.autoHead {
+		method = http.MethodGet
+		leaf, params, ok = r.match(method, req)
+	}
+	if !ok {
+		if r.methodNotAllowed != nil {
+			if methods := r.allowedMethods(req); len(methods) > 0 {
+				w.Header().Set("Allow", strings.Join(methods, ", "))
+				r.methodNotAllowed(w, req)
+				return
+			}
+		}
 		r.notFound(w, req)
 		return
 	}
 
-	params["route"] = leaf.Route()
-	req.Pattern = req.Method + " " + leaf.Route()
+	req.Pattern = method + " " + leaf.Route()
 	leaf.Handler()(w, req, params)
 }
 
@@ -418,17 +601,24 @@ func (r *router) URLPath(name string, pairs ...string) string {
 		panic("route with given name does not exist: " + name)
 	}
 
-	vals := make(map[string]string, len(pairs)/2)
+	vals, withOptional := urlPathValues(pairs)
+	return leaf.URLPath(vals, withOptional)
+}
+
+// urlPathValues returns values of bind parameters from the key-value pairs,
+// and whether to include the optional segment as indicated by the special
+// "withOptional" key.
+func urlPathValues(pairs []string) (vals map[string]string, withOptional bool) {
+	vals = make(map[string]string, len(pairs)/2)
 	for i := 1; i < len(pairs); i += 2 {
 		vals[pairs[i-1]] = pairs[i]
 	}
 
-	withOptional := false
 	if vals["withOptional"] == "true" {
 		withOptional = true
 		delete(vals, "withOptional")
 	}
-	return leaf.URLPath(vals, withOptional)
+	return vals, withOptional
 }
 
 // Combo creates and returns new ComboRoute with common handlers for the route.
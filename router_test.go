@@ -256,6 +256,142 @@ func TestRouter_AutoHead(t *testing.T) {
 	})
 }
 
+func TestRouter_AutoHeadFallback(t *testing.T) {
+	f := New()
+	f.Get("/before", func() string { return "before" })
+	f.Route(http.MethodGet, "/route", []Handler{func() string { return "route" }})
+	f.Combo("/combo").Get(func() string { return "combo" })
+	f.Get("/explicit", func() string { return "get" })
+	f.Head("/explicit", func(c Context) { c.ResponseWriter().Header().Set("X-Head", "true") })
+	f.Head("/explicit-status", func(c Context) {
+		c.ResponseWriter().Header().Set("X-Head", "true")
+		c.ResponseWriter().WriteHeader(http.StatusOK)
+	})
+	f.Get("/empty", func(c Context) { c.ResponseWriter().WriteHeader(http.StatusOK) })
+	f.Get("/users/{name}", func(c Context) string { return c.Param("name") })
+	f.AutoHead(true)
+	f.Get("/after", func() string { return "after" })
+
+	tests := []struct {
+		path              string
+		wantContentLength string
+		wantHead          bool
+	}{
+		{path: "/before", wantContentLength: "6"},
+		{path: "/route", wantContentLength: "5"},
+		{path: "/combo", wantContentLength: "5"},
+		{path: "/explicit", wantHead: true},
+		{path: "/explicit-status", wantHead: true},
+		{path: "/empty"},
+		{path: "/users/flamego", wantContentLength: "7"},
+		{path: "/after", wantContentLength: "5"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodHead, test.path, nil)
+			require.NoError(t, err)
+
+			f.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Empty(t, resp.Body.String())
+			assert.Equal(t, test.wantContentLength, resp.Header().Get("Content-Length"))
+			assert.Equal(t, test.wantHead, resp.Header().Get("X-Head") == "true")
+		})
+	}
+
+	t.Run("wrapped response writer", func(t *testing.T) {
+		f := New()
+		f.Use(func(c Context) {
+			c.MapTo(&wrappedResponseWriter{ResponseWriter: c.ResponseWriter()}, (*http.ResponseWriter)(nil))
+		})
+		f.Get("/", func(w http.ResponseWriter) {
+			_, _ = w.Write([]byte("wrapped"))
+		})
+		f.AutoHead(true)
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodHead, "/", nil)
+		require.NoError(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "7", resp.Header().Get("Content-Length"))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodHead, "/404", nil)
+		require.NoError(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+	})
+}
+
+type wrappedResponseWriter struct {
+	http.ResponseWriter
+}
+
+func TestRouter_MethodNotAllowed(t *testing.T) {
+	f := New()
+	f.AutoHead(true)
+	f.Get("/users/{name}", func() {})
+	f.Put("/users/{name}", func() {})
+
+	t.Run("not set", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodDelete, "/users/flamego", nil)
+		require.NoError(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.Empty(t, resp.Header().Get("Allow"))
+	})
+
+	f.MethodNotAllowed(func(c Context) {
+		c.ResponseWriter().WriteHeader(http.StatusMethodNotAllowed)
+	})
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		wantCode  int
+		wantAllow string
+	}{
+		{
+			name:      "method not allowed",
+			method:    http.MethodDelete,
+			path:      "/users/flamego",
+			wantCode:  http.StatusMethodNotAllowed,
+			wantAllow: "GET, PUT, HEAD",
+		},
+		{
+			name:     "not found",
+			method:   http.MethodDelete,
+			path:     "/404",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req, err := http.NewRequest(test.method, test.path, nil)
+			require.NoError(t, err)
+
+			f.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.wantCode, resp.Code)
+			assert.Equal(t, test.wantAllow, resp.Header().Get("Allow"))
+		})
+	}
+}
+
 func TestRouter_DuplicatedRoutes(t *testing.T) {
 	contextCreator := func(w http.ResponseWriter, r *http.Request, params route.Params, handlers []Handler, urlPath urlPather) internalContext {
 		return newMockContext()
@@ -471,6 +607,89 @@ func TestRoute_Match(t *testing.T) {
 	})
 }
 
+func TestRoute_Alias(t *testing.T) {
+	t.Run("aliases share handlers", func(t *testing.T) {
+		f := New()
+		f.Group("/api", func() {
+			f.Get("/users/{name}", func(c Context) string {
+				return c.Param("name")
+			}).Alias("/u/{name}", "/people/{name}").Name("user")
+		})
+
+		for _, path := range []string{"/api/users/joe", "/api/u/joe", "/api/people/joe"} {
+			t.Run(path, func(t *testing.T) {
+				resp := httptest.NewRecorder()
+				req, err := http.NewRequest(http.MethodGet, path, nil)
+				require.NoError(t, err)
+
+				f.ServeHTTP(resp, req)
+
+				assert.Equal(t, http.StatusOK, resp.Code)
+				assert.Equal(t, "joe", resp.Body.String())
+			})
+		}
+
+		assert.Equal(t, "/api/users/joe", f.URLPath("user", "name", "joe"))
+	})
+
+	t.Run("redirect aliases", func(t *testing.T) {
+		f := New()
+		f.Get("/users/{name}/?{tab}", func() {}).
+			Alias("/u/{name}/?{tab}").
+			RedirectAliases()
+
+		tests := []struct {
+			path string
+			want string
+		}{
+			{path: "/u/joe", want: "/users/joe"},
+			{path: "/u/joe/repos", want: "/users/joe/repos"},
+			{path: "/u/joe?tab=1", want: "/users/joe?tab=1"},
+			{path: "/u/a%20b", want: "/users/a%20b"},
+		}
+		for _, test := range tests {
+			t.Run(test.path, func(t *testing.T) {
+				resp := httptest.NewRecorder()
+				req, err := http.NewRequest(http.MethodGet, test.path, nil)
+				require.NoError(t, err)
+
+				f.ServeHTTP(resp, req)
+
+				assert.Equal(t, http.StatusMovedPermanently, resp.Code)
+				assert.Equal(t, test.want, resp.Header().Get("Location"))
+			})
+		}
+	})
+
+	t.Run("aliases inherit matching criteria", func(t *testing.T) {
+		f := New()
+		f.Get("/", func() {}).
+			Headers("X-Special", "yes").
+			Alias("/home").
+			Match(func(r *http.Request) bool { return r.Header.Get("X-Other") == "yes" })
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/home", nil)
+		require.NoError(t, err)
+		f.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+
+		resp = httptest.NewRecorder()
+		req.Header.Set("X-Special", "yes")
+		req.Header.Set("X-Other", "yes")
+		f.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("duplicated alias", func(t *testing.T) {
+		f := New()
+		defer func() {
+			assert.Contains(t, recover(), `unable to add route "/home" with method GET`)
+		}()
+		f.Get("/", func() {}).Alias("/home", "/home")
+	})
+}
+
 func TestRoute_Name(t *testing.T) {
 	contextCreator := func(w http.ResponseWriter, r *http.Request, params route.Params, handlers []Handler, urlPath urlPather) internalContext {
 		return newMockContext()
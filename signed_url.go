@@ -0,0 +1,108 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	signedURLExpires   = "expires"   // The query parameter name of the expiry time.
+	signedURLSignature = "signature" // The query parameter name of the signature.
+)
+
+func (r *router) SigningKey(key []byte) {
+	r.signingKey = key
+}
+
+func (r *router) SignedURLPath(name string, expiry time.Duration, pairs ...string) string {
+	if len(r.signingKey) == 0 {
+		panic("signing key is not set")
+	}
+
+	leaf, ok := r.namedRoutes[name]
+	if !ok {
+		panic("route with given name does not exist: " + name)
+	}
+
+	vals, withOptional := urlPathValues(pairs)
+	path, err := leaf.BuildURLPath(vals, withOptional)
+	if err != nil {
+		panic(fmt.Sprintf("unable to build URL path of route %q: %v", name, err))
+	}
+
+	query := make(url.Values, 2)
+	if expiry > 0 {
+		query.Set(signedURLExpires, strconv.FormatInt(time.Now().Add(expiry).Unix(), 10))
+	}
+	query.Set(signedURLSignature, signURL(r.signingKey, path, query))
+	return path + "?" + query.Encode()
+}
+
+// signURL computes and returns the HMAC-SHA256 signature of the path and the
+// query (excluding the signature itself) with the given key.
+func signURL(key []byte, path string, query url.Values) string {
+	q := make(url.Values, len(query))
+	for k, v := range query {
+		if k == signedURLSignature {
+			continue
+		}
+		q[k] = v
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(path))
+	mac.Write([]byte("?"))
+	mac.Write([]byte(q.Encode()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// RequireSignature makes the route to only accept requests with URLs that are
+// signed by Router.SignedURLPath, which is verified against the escaped path
+// that is actually requested. Requests with URLs that are tampered or expired
+// are responded with http.StatusForbidden. Requests to aliases of the route are
+// always rejected because signed URLs are built for the route path. It panics
+// at request time if the signing key is not set.
+//
+// For example:
+//
+//	f.SigningKey([]byte("secret"))
+//	f.Get("/download/{id}", ...).RequireSignature().Name("download")
+//
+//	f.SignedURLPath("download", time.Hour, "id", "1") // => /download/1?expires=...&signature=...
+func (r *Route) RequireSignature() *Route {
+	handler := ContextInvoker(func(c Context) {
+		key := r.router.signingKey
+		if len(key) == 0 {
+			panic("signing key is not set")
+		}
+
+		query := c.Request().URL.Query()
+		want := signURL(key, c.Request().URL.EscapedPath(), query)
+		got := query.Get(signedURLSignature)
+		if !hmac.Equal([]byte(want), []byte(got)) {
+			http.Error(c.ResponseWriter(), "invalid signature", http.StatusForbidden)
+			return
+		}
+
+		if v := query.Get(signedURLExpires); v != "" {
+			expires, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || time.Now().Unix() > expires {
+				http.Error(c.ResponseWriter(), "expired signature", http.StatusForbidden)
+				return
+			}
+		}
+	})
+
+	r.handlers = append([]Handler{handler}, r.handlers...)
+	return r
+}
@@ -0,0 +1,165 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_SignedURLPath(t *testing.T) {
+	f := New()
+	f.Get("/download/{id}", func(c Context) string {
+		return "file " + c.Param("id")
+	}).RequireSignature().Name("download")
+
+	t.Run("no signing key", func(t *testing.T) {
+		defer func() {
+			assert.Equal(t, "signing key is not set", recover())
+		}()
+		f.SignedURLPath("download", time.Hour, "id", "1")
+	})
+
+	f.SigningKey([]byte("secret"))
+
+	t.Run("expiry", func(t *testing.T) {
+		u, err := url.Parse(f.SignedURLPath("download", time.Hour, "id", "1"))
+		require.NoError(t, err)
+		assert.Equal(t, "/download/1", u.Path)
+
+		expires, err := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+		require.NoError(t, err)
+		assert.InDelta(t, time.Now().Add(time.Hour).Unix(), expires, 5)
+		assert.NotEmpty(t, u.Query().Get("signature"))
+	})
+
+	t.Run("never expires", func(t *testing.T) {
+		u, err := url.Parse(f.SignedURLPath("download", 0, "id", "1"))
+		require.NoError(t, err)
+		assert.False(t, u.Query().Has("expires"))
+		assert.NotEmpty(t, u.Query().Get("signature"))
+	})
+
+	t.Run("escaped", func(t *testing.T) {
+		u, err := url.Parse(f.SignedURLPath("download", 0, "id", "a b"))
+		require.NoError(t, err)
+		assert.Equal(t, "/download/a%20b", u.EscapedPath())
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		defer func() {
+			assert.Equal(t, `unable to build URL path of route "download": value "a/b" of bind parameter "id" contains slash`, recover())
+		}()
+		f.SignedURLPath("download", 0, "id", "a/b")
+	})
+}
+
+func TestRoute_RequireSignature(t *testing.T) {
+	f := New()
+	f.SigningKey([]byte("secret"))
+	f.Get("/download/{id}", func(c Context) string {
+		return "file " + c.Param("id")
+	}).RequireSignature().Alias("/d/{id}").Name("download")
+
+	tamper := func(rawURL string, fn func(u *url.URL, q url.Values)) string {
+		u, err := url.Parse(rawURL)
+		require.NoError(t, err)
+		q := u.Query()
+		fn(u, q)
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+
+	signed := f.SignedURLPath("download", time.Hour, "id", "1")
+	tests := []struct {
+		name     string
+		url      string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "valid",
+			url:      signed,
+			wantCode: http.StatusOK,
+			wantBody: "file 1",
+		},
+		{
+			name:     "never expires",
+			url:      f.SignedURLPath("download", 0, "id", "1"),
+			wantCode: http.StatusOK,
+			wantBody: "file 1",
+		},
+		{
+			name:     "escaped",
+			url:      f.SignedURLPath("download", 0, "id", "a b"),
+			wantCode: http.StatusOK,
+			wantBody: "file a b",
+		},
+		{
+			name: "alias",
+			url: tamper(signed, func(u *url.URL, _ url.Values) {
+				u.Path = "/d/1"
+			}),
+			wantCode: http.StatusForbidden,
+			wantBody: "invalid signature\n",
+		},
+		{
+			name:     "unsigned",
+			url:      "/download/1",
+			wantCode: http.StatusForbidden,
+			wantBody: "invalid signature\n",
+		},
+		{
+			name: "tampered path",
+			url: tamper(signed, func(u *url.URL, _ url.Values) {
+				u.Path = "/download/2"
+			}),
+			wantCode: http.StatusForbidden,
+			wantBody: "invalid signature\n",
+		},
+		{
+			name: "tampered expiry",
+			url: tamper(signed, func(_ *url.URL, q url.Values) {
+				q.Set("expires", strconv.FormatInt(time.Now().Add(24*time.Hour).Unix(), 10))
+			}),
+			wantCode: http.StatusForbidden,
+			wantBody: "invalid signature\n",
+		},
+		{
+			name: "removed expiry",
+			url: tamper(signed, func(_ *url.URL, q url.Values) {
+				q.Del("expires")
+			}),
+			wantCode: http.StatusForbidden,
+			wantBody: "invalid signature\n",
+		},
+		{
+			name:     "expired",
+			url:      f.SignedURLPath("download", time.Nanosecond, "id", "1"),
+			wantCode: http.StatusForbidden,
+			wantBody: "expired signature\n",
+		},
+	}
+	time.Sleep(1100 * time.Millisecond) // Make sure the "expired" case has expired
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, test.url, nil)
+			require.NoError(t, err)
+
+			f.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.wantCode, resp.Code)
+			assert.Equal(t, test.wantBody, resp.Body.String())
+		})
+	}
+}
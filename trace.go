@@ -0,0 +1,168 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TraceOptions contains options for the Flame.TraceHandlers.
+type TraceOptions struct {
+	// ServerTiming indicates whether to respond with the "Server-Timing" header
+	// that contains the self duration of each handler up to the time when the
+	// response is written.
+	ServerTiming bool
+}
+
+// HandlerTrace is the trace of a handler that is invoked in the context chain.
+type HandlerTrace struct {
+	// Index is the index of the handler in the context chain.
+	Index int
+	// Name is the function name of the handler.
+	Name string
+	// Duration is the time spent in the handler, including handlers that are
+	// invoked via Context.Next.
+	Duration time.Duration
+	// Self is the time spent in the handler, excluding handlers that are invoked
+	// via Context.Next.
+	Self time.Duration
+	// CalledNext indicates whether the handler called Context.Next.
+	CalledNext bool
+	// Wrote indicates whether the handler wrote the response.
+	Wrote bool
+}
+
+// traceFrame is the state of a handler that is being traced.
+type traceFrame struct {
+	trace       int           // The index of the trace.
+	started     time.Time     // The time when the handler is started.
+	nested      time.Duration // The time spent in handlers that are invoked via Next.
+	nextStarted time.Time     // The time when the current Next is called.
+	inNext      bool          // Whether the handler is waiting for the Next to return.
+	written     bool          // Whether the response has been written as of the last checkpoint.
+}
+
+// TraceHandlers enables tracing of handlers for every request, which records
+// the function name, duration, whether called Context.Next, and whether wrote
+// the response of each handler in the context chain. Traces are available via
+// Context.HandlerTraces. It is intended for diagnosing slow requests in
+// development, as it adds overhead to every handler invocation.
+func (f *Flame) TraceHandlers(opts ...TraceOptions) {
+	var opt TraceOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	f.traceOptions = &opt
+}
+
+// handlerName returns the function name of the handler.
+func handlerName(h Handler) string {
+	return runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name()
+}
+
+func (c *context) setTraceOptions(opts *TraceOptions) {
+	c.traceOptions = opts
+	if opts == nil || !opts.ServerTiming {
+		return
+	}
+
+	c.responseWriter.Before(func(w ResponseWriter) {
+		traces := c.HandlerTraces()
+		metrics := make([]string, 0, len(traces))
+		for i, t := range traces {
+			metrics = append(metrics, fmt.Sprintf("h%d;dur=%s;desc=%s",
+				i, strconv.FormatFloat(float64(t.Self)/float64(time.Millisecond), 'f', 3, 64), strconv.Quote(t.Name)))
+		}
+		if len(metrics) > 0 {
+			w.Header().Add("Server-Timing", strings.Join(metrics, ", "))
+		}
+	})
+}
+
+// startTrace starts tracing the handler and returns the index of its frame.
+func (c *context) startTrace(h Handler) int {
+	c.traces = append(c.traces, HandlerTrace{
+		Index: c.index,
+		Name:  handlerName(h),
+	})
+	c.traceFrames = append(c.traceFrames, traceFrame{
+		trace:   len(c.traces) - 1,
+		started: time.Now(),
+		written: c.ResponseWriter().Written(),
+	})
+	return len(c.traceFrames) - 1
+}
+
+// endTrace ends tracing the handler of the given frame. Frames above it (i.e.
+// handlers that did not return because of a panic) are ended as well.
+func (c *context) endTrace(frame int) {
+	now := time.Now()
+	for i := len(c.traceFrames) - 1; i >= frame; i-- {
+		f := c.traceFrames[i]
+		t := &c.traces[f.trace]
+		t.Duration = now.Sub(f.started)
+		t.Self = t.Duration - f.nested
+		if !f.written && c.ResponseWriter().Written() {
+			t.Wrote = true
+		}
+	}
+	c.traceFrames = c.traceFrames[:frame]
+}
+
+// enterNext records the handler that is currently traced has called Next, and
+// returns the index of its frame.
+func (c *context) enterNext() int {
+	frame := len(c.traceFrames) - 1
+	f := &c.traceFrames[frame]
+	t := &c.traces[f.trace]
+	t.CalledNext = true
+	if !f.written && c.ResponseWriter().Written() {
+		t.Wrote = true
+	}
+	f.inNext = true
+	f.nextStarted = time.Now()
+	return frame
+}
+
+// exitNext records the Next called by the handler of the given frame has
+// returned.
+func (c *context) exitNext(frame int) {
+	if frame >= len(c.traceFrames) {
+		return
+	}
+	if len(c.traceFrames) > frame+1 {
+		c.endTrace(frame + 1)
+	}
+
+	f := &c.traceFrames[frame]
+	f.nested += time.Since(f.nextStarted)
+	f.inNext = false
+	f.written = c.ResponseWriter().Written()
+}
+
+func (c *context) HandlerTraces() []HandlerTrace {
+	if c.traceOptions == nil {
+		return nil
+	}
+
+	now := time.Now()
+	traces := make([]HandlerTrace, len(c.traces))
+	copy(traces, c.traces)
+	for _, f := range c.traceFrames {
+		t := &traces[f.trace]
+		t.Duration = now.Sub(f.started)
+		nested := f.nested
+		if f.inNext {
+			nested += now.Sub(f.nextStarted)
+		}
+		t.Self = t.Duration - nested
+	}
+	return traces
+}
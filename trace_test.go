@@ -0,0 +1,80 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func traceMiddleware(c Context) {
+	time.Sleep(10 * time.Millisecond)
+	c.Next()
+}
+
+func traceHandler() string {
+	time.Sleep(20 * time.Millisecond)
+	return "ok"
+}
+
+func TestFlame_TraceHandlers(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Get("/", func(c Context) {
+			assert.Nil(t, c.HandlerTraces())
+		})
+
+		resp := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		require.NoError(t, err)
+
+		f.ServeHTTP(resp, req)
+
+		assert.Empty(t, resp.Header().Get("Server-Timing"))
+	})
+
+	var traces []HandlerTrace
+	f := NewWithLogger(&bytes.Buffer{})
+	f.TraceHandlers(TraceOptions{ServerTiming: true})
+	f.Use(func(c Context) {
+		c.After(func() { traces = c.HandlerTraces() })
+	})
+	f.Use(traceMiddleware)
+	f.Get("/", traceHandler)
+
+	resp := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, err)
+
+	f.ServeHTTP(resp, req)
+
+	require.Len(t, traces, 3)
+
+	assert.Equal(t, 1, traces[1].Index)
+	assert.True(t, strings.HasSuffix(traces[1].Name, ".traceMiddleware"))
+	assert.True(t, traces[1].CalledNext)
+	assert.False(t, traces[1].Wrote)
+	assert.GreaterOrEqual(t, traces[1].Duration, 30*time.Millisecond)
+	assert.GreaterOrEqual(t, traces[1].Self, 10*time.Millisecond)
+	assert.LessOrEqual(t, traces[1].Self, traces[1].Duration-traces[2].Duration)
+
+	assert.Equal(t, 2, traces[2].Index)
+	assert.True(t, strings.HasSuffix(traces[2].Name, ".traceHandler"))
+	assert.False(t, traces[2].CalledNext)
+	assert.True(t, traces[2].Wrote)
+	assert.GreaterOrEqual(t, traces[2].Self, 20*time.Millisecond)
+	assert.Equal(t, traces[2].Duration, traces[2].Self)
+
+	serverTiming := resp.Header().Get("Server-Timing")
+	assert.Contains(t, serverTiming, "h1;dur=")
+	assert.Contains(t, serverTiming, `desc="github.com/flamego/flamego.traceHandler"`)
+}
@@ -0,0 +1,241 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"reflect"
+
+	"github.com/flamego/flamego/inject"
+)
+
+// Typed handlers are inject.FastInvoker implementations of functions with type
+// parameters, which avoid the cost of invoking handlers via reflection without
+// hand-written or generated adapters. The number in the function name is the
+// number of arguments, and the "R" or "R2" suffix indicates the function
+// returns one or two values respectively. For example:
+//
+//	f.Get("/", flamego.H2R2(func(c flamego.Context, db *sql.DB) (int, error) {
+//	    ...
+//	}))
+
+type handler0 func()
+
+// H0 returns an inject.FastInvoker of the handler that takes no arguments.
+func H0(fn func()) inject.FastInvoker {
+	return handler0(fn)
+}
+
+func (invoke handler0) Invoke([]interface{}) ([]reflect.Value, error) {
+	invoke()
+	return nil, nil
+}
+
+type handler1[A any] func(A)
+
+// H1 returns an inject.FastInvoker of the handler that takes one argument.
+func H1[A any](fn func(A)) inject.FastInvoker {
+	return handler1[A](fn)
+}
+
+func (invoke handler1[A]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	arg1, _ := args[0].(A)
+	invoke(arg1)
+	return nil, nil
+}
+
+type handler2[A, B any] func(A, B)
+
+// H2 returns an inject.FastInvoker of the handler that takes two arguments.
+func H2[A, B any](fn func(A, B)) inject.FastInvoker {
+	return handler2[A, B](fn)
+}
+
+func (invoke handler2[A, B]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	arg1, _ := args[0].(A)
+	arg2, _ := args[1].(B)
+	invoke(arg1, arg2)
+	return nil, nil
+}
+
+type handler3[A, B, C any] func(A, B, C)
+
+// H3 returns an inject.FastInvoker of the handler that takes three arguments.
+func H3[A, B, C any](fn func(A, B, C)) inject.FastInvoker {
+	return handler3[A, B, C](fn)
+}
+
+func (invoke handler3[A, B, C]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	arg1, _ := args[0].(A)
+	arg2, _ := args[1].(B)
+	arg3, _ := args[2].(C)
+	invoke(arg1, arg2, arg3)
+	return nil, nil
+}
+
+type handler4[A, B, C, D any] func(A, B, C, D)
+
+// H4 returns an inject.FastInvoker of the handler that takes four arguments.
+func H4[A, B, C, D any](fn func(A, B, C, D)) inject.FastInvoker {
+	return handler4[A, B, C, D](fn)
+}
+
+func (invoke handler4[A, B, C, D]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	arg1, _ := args[0].(A)
+	arg2, _ := args[1].(B)
+	arg3, _ := args[2].(C)
+	arg4, _ := args[3].(D)
+	invoke(arg1, arg2, arg3, arg4)
+	return nil, nil
+}
+
+type handler0r[R any] func() R
+
+// H0R returns an inject.FastInvoker of the handler that takes no arguments
+// and returns one value.
+func H0R[R any](fn func() R) inject.FastInvoker {
+	return handler0r[R](fn)
+}
+
+func (invoke handler0r[R]) Invoke([]interface{}) ([]reflect.Value, error) {
+	ret1 := invoke()
+	return []reflect.Value{reflect.ValueOf(&ret1).Elem()}, nil
+}
+
+type handler1r[A, R any] func(A) R
+
+// H1R returns an inject.FastInvoker of the handler that takes one argument
+// and returns one value.
+func H1R[A, R any](fn func(A) R) inject.FastInvoker {
+	return handler1r[A, R](fn)
+}
+
+func (invoke handler1r[A, R]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	arg1, _ := args[0].(A)
+	ret1 := invoke(arg1)
+	return []reflect.Value{reflect.ValueOf(&ret1).Elem()}, nil
+}
+
+type handler2r[A, B, R any] func(A, B) R
+
+// H2R returns an inject.FastInvoker of the handler that takes two arguments
+// and returns one value.
+func H2R[A, B, R any](fn func(A, B) R) inject.FastInvoker {
+	return handler2r[A, B, R](fn)
+}
+
+func (invoke handler2r[A, B, R]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	arg1, _ := args[0].(A)
+	arg2, _ := args[1].(B)
+	ret1 := invoke(arg1, arg2)
+	return []reflect.Value{reflect.ValueOf(&ret1).Elem()}, nil
+}
+
+type handler3r[A, B, C, R any] func(A, B, C) R
+
+// H3R returns an inject.FastInvoker of the handler that takes three arguments
+// and returns one value.
+func H3R[A, B, C, R any](fn func(A, B, C) R) inject.FastInvoker {
+	return handler3r[A, B, C, R](fn)
+}
+
+func (invoke handler3r[A, B, C, R]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	arg1, _ := args[0].(A)
+	arg2, _ := args[1].(B)
+	arg3, _ := args[2].(C)
+	ret1 := invoke(arg1, arg2, arg3)
+	return []reflect.Value{reflect.ValueOf(&ret1).Elem()}, nil
+}
+
+type handler4r[A, B, C, D, R any] func(A, B, C, D) R
+
+// H4R returns an inject.FastInvoker of the handler that takes four arguments
+// and returns one value.
+func H4R[A, B, C, D, R any](fn func(A, B, C, D) R) inject.FastInvoker {
+	return handler4r[A, B, C, D, R](fn)
+}
+
+func (invoke handler4r[A, B, C, D, R]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	arg1, _ := args[0].(A)
+	arg2, _ := args[1].(B)
+	arg3, _ := args[2].(C)
+	arg4, _ := args[3].(D)
+	ret1 := invoke(arg1, arg2, arg3, arg4)
+	return []reflect.Value{reflect.ValueOf(&ret1).Elem()}, nil
+}
+
+type handler0r2[R1, R2 any] func() (R1, R2)
+
+// H0R2 returns an inject.FastInvoker of the handler that takes no arguments
+// and returns two values.
+func H0R2[R1, R2 any](fn func() (R1, R2)) inject.FastInvoker {
+	return handler0r2[R1, R2](fn)
+}
+
+func (invoke handler0r2[R1, R2]) Invoke([]interface{}) ([]reflect.Value, error) {
+	ret1, ret2 := invoke()
+	return []reflect.Value{reflect.ValueOf(&ret1).Elem(), reflect.ValueOf(&ret2).Elem()}, nil
+}
+
+type handler1r2[A, R1, R2 any] func(A) (R1, R2)
+
+// H1R2 returns an inject.FastInvoker of the handler that takes one argument
+// and returns two values.
+func H1R2[A, R1, R2 any](fn func(A) (R1, R2)) inject.FastInvoker {
+	return handler1r2[A, R1, R2](fn)
+}
+
+func (invoke handler1r2[A, R1, R2]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	arg1, _ := args[0].(A)
+	ret1, ret2 := invoke(arg1)
+	return []reflect.Value{reflect.ValueOf(&ret1).Elem(), reflect.ValueOf(&ret2).Elem()}, nil
+}
+
+type handler2r2[A, B, R1, R2 any] func(A, B) (R1, R2)
+
+// H2R2 returns an inject.FastInvoker of the handler that takes two arguments
+// and returns two values.
+func H2R2[A, B, R1, R2 any](fn func(A, B) (R1, R2)) inject.FastInvoker {
+	return handler2r2[A, B, R1, R2](fn)
+}
+
+func (invoke handler2r2[A, B, R1, R2]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	arg1, _ := args[0].(A)
+	arg2, _ := args[1].(B)
+	ret1, ret2 := invoke(arg1, arg2)
+	return []reflect.Value{reflect.ValueOf(&ret1).Elem(), reflect.ValueOf(&ret2).Elem()}, nil
+}
+
+type handler3r2[A, B, C, R1, R2 any] func(A, B, C) (R1, R2)
+
+// H3R2 returns an inject.FastInvoker of the handler that takes three arguments
+// and returns two values.
+func H3R2[A, B, C, R1, R2 any](fn func(A, B, C) (R1, R2)) inject.FastInvoker {
+	return handler3r2[A, B, C, R1, R2](fn)
+}
+
+func (invoke handler3r2[A, B, C, R1, R2]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	arg1, _ := args[0].(A)
+	arg2, _ := args[1].(B)
+	arg3, _ := args[2].(C)
+	ret1, ret2 := invoke(arg1, arg2, arg3)
+	return []reflect.Value{reflect.ValueOf(&ret1).Elem(), reflect.ValueOf(&ret2).Elem()}, nil
+}
+
+type handler4r2[A, B, C, D, R1, R2 any] func(A, B, C, D) (R1, R2)
+
+// H4R2 returns an inject.FastInvoker of the handler that takes four arguments
+// and returns two values.
+func H4R2[A, B, C, D, R1, R2 any](fn func(A, B, C, D) (R1, R2)) inject.FastInvoker {
+	return handler4r2[A, B, C, D, R1, R2](fn)
+}
+
+func (invoke handler4r2[A, B, C, D, R1, R2]) Invoke(args []interface{}) ([]reflect.Value, error) {
+	arg1, _ := args[0].(A)
+	arg2, _ := args[1].(B)
+	arg3, _ := args[2].(C)
+	arg4, _ := args[3].(D)
+	ret1, ret2 := invoke(arg1, arg2, arg3, arg4)
+	return []reflect.Value{reflect.ValueOf(&ret1).Elem(), reflect.ValueOf(&ret2).Elem()}, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flamego/flamego/inject"
+)
+
+func TestTypedHandlers(t *testing.T) {
+	f := NewWithLogger(&bytes.Buffer{})
+	f.Map(42)
+	f.Use(H1(func(c Context) { c.Map("typed") }))
+	f.Get("/h2", H2(func(c Context, s string) {
+		_, _ = c.ResponseWriter().Write([]byte(s))
+	}))
+	f.Get("/h1r", H1R(func(n int) string { return "number " + strconv.Itoa(n) }))
+	f.Get("/h2r2", H2R2(func(s string, n int) (int, error) {
+		return http.StatusConflict, errors.New(s)
+	}))
+	f.Get("/h0r2", H0R2(func() (int, string) { return http.StatusTeapot, "teapot" }))
+
+	tests := []struct {
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{path: "/h2", wantCode: http.StatusOK, wantBody: "typed"},
+		{path: "/h1r", wantCode: http.StatusOK, wantBody: "number 42"},
+		{path: "/h2r2", wantCode: http.StatusConflict, wantBody: "typed"},
+		{path: "/h0r2", wantCode: http.StatusTeapot, wantBody: "teapot"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, test.path, nil)
+			require.NoError(t, err)
+
+			f.ServeHTTP(resp, req)
+
+			assert.Equal(t, test.wantCode, resp.Code)
+			assert.Equal(t, test.wantBody, resp.Body.String())
+		})
+	}
+
+	t.Run("recognized as fast invokers", func(t *testing.T) {
+		h := H4R(func(Context, string, int, Render) error { return nil })
+		_, ok := validateAndWrapHandler(h, nil).(handler4r[Context, string, int, Render, error])
+		assert.True(t, ok)
+	})
+}
+
+func BenchmarkTypedHandler(b *testing.B) {
+	fn := func(c Context, s string, n int) (int, error) { return n, nil }
+
+	inj := inject.New()
+	inj.MapTo(&context{}, (*Context)(nil))
+	inj.Map("some dependency", 42)
+
+	b.Run("reflection", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = inj.Invoke(fn)
+		}
+	})
+
+	b.Run("typed", func(b *testing.B) {
+		h := H3R2(fn)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = inj.Invoke(h)
+		}
+	})
+}
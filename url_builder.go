@@ -0,0 +1,131 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+func (r *router) BaseURL(baseURL string) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		panic(fmt.Sprintf("unable to parse base URL %q: %v", baseURL, err))
+	} else if u.Scheme == "" || u.Host == "" {
+		panic(fmt.Sprintf("base URL %q must have scheme and host", baseURL))
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+	u.RawQuery = ""
+	u.Fragment = ""
+	r.baseURL = u
+}
+
+func (r *router) URL(name string) *URLBuilder {
+	return &URLBuilder{
+		router: r,
+		name:   name,
+		params: make(map[string]string),
+		query:  make(url.Values),
+	}
+}
+
+// URLBuilder is a builder for the URL of a named route. Values of bind
+// parameters are validated against the route (e.g. the regex), and errors are
+// returned when building the URL.
+//
+// For example:
+//
+//	f.Get("/users/{name}/?{tab}", ...).Name("UsersName")
+//
+//	f.URL("UsersName").
+//	    Param("name", "joe").
+//	    Query("sort", "stars").
+//	    Build() // => "/users/joe?sort=stars", nil
+type URLBuilder struct {
+	router       *router
+	name         string            // The name of the route.
+	params       map[string]string // The values of bind parameters.
+	withOptional bool              // Whether to include the optional segment.
+	query        url.Values        // The query parameters.
+	fragment     string            // The fragment.
+}
+
+// Param sets the value of the bind parameter.
+func (b *URLBuilder) Param(name, value string) *URLBuilder {
+	b.params[name] = value
+	return b
+}
+
+// WithOptional includes the optional segment of the route, which requires
+// values of all bind parameters of the optional segment.
+func (b *URLBuilder) WithOptional() *URLBuilder {
+	b.withOptional = true
+	return b
+}
+
+// Query adds values to the query parameter.
+func (b *URLBuilder) Query(name string, values ...string) *URLBuilder {
+	for _, v := range values {
+		b.query.Add(name, v)
+	}
+	return b
+}
+
+// Fragment sets the fragment (without the leading "#").
+func (b *URLBuilder) Fragment(fragment string) *URLBuilder {
+	b.fragment = fragment
+	return b
+}
+
+// Build builds and returns the URL relative to the root, i.e. the "path"
+// portion of the URL along with query parameters and fragment.
+func (b *URLBuilder) Build() (string, error) {
+	u, err := b.build()
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// Absolute builds and returns the absolute URL using the base URL that is set
+// by Router.BaseURL.
+func (b *URLBuilder) Absolute() (string, error) {
+	if b.router.baseURL == nil {
+		return "", errors.New("base URL is not set")
+	}
+
+	u, err := b.build()
+	if err != nil {
+		return "", err
+	}
+
+	// The base URL never has trailing slash, query parameters or fragment.
+	return b.router.baseURL.String() + u.String(), nil
+}
+
+func (b *URLBuilder) build() (*url.URL, error) {
+	leaf, ok := b.router.namedRoutes[b.name]
+	if !ok {
+		return nil, errors.Errorf("route with given name does not exist: %s", b.name)
+	}
+
+	path, err := leaf.BuildURLPath(b.params, b.withOptional)
+	if err != nil {
+		return nil, errors.Wrapf(err, "build URL path of route %q", b.name)
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse URL path %q", path)
+	}
+	u.RawQuery = b.query.Encode()
+	u.Fragment = b.fragment
+	return u, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter_BaseURL(t *testing.T) {
+	f := New()
+
+	t.Run("invalid base URL", func(t *testing.T) {
+		defer func() {
+			assert.Contains(t, recover(), "unable to parse base URL")
+		}()
+		f.BaseURL("://flamego.dev")
+	})
+
+	t.Run("missing host", func(t *testing.T) {
+		defer func() {
+			assert.Equal(t, `base URL "/webapi" must have scheme and host`, recover())
+		}()
+		f.BaseURL("/webapi")
+	})
+}
+
+func TestURLBuilder(t *testing.T) {
+	f := New()
+	f.Get("/{owner}/{repo}/settings/?{tab}", func() {}).Name("repo.settings")
+	f.Get("/issues/{id: /[0-9]+/}", func() {}).Name("issue")
+
+	t.Run("Build", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			builder *URLBuilder
+			want    string
+			wantErr string
+		}{
+			{
+				name:    "name not exists",
+				builder: f.URL("404"),
+				wantErr: "route with given name does not exist: 404",
+			},
+			{
+				name:    "good",
+				builder: f.URL("repo.settings").Param("owner", "flamego").Param("repo", "flamego"),
+				want:    "/flamego/flamego/settings",
+			},
+			{
+				name:    "missing value",
+				builder: f.URL("repo.settings").Param("owner", "flamego"),
+				wantErr: `build URL path of route "repo.settings": missing value for bind parameter "repo"`,
+			},
+			{
+				name: "with optional",
+				builder: f.URL("repo.settings").
+					Param("owner", "flamego").
+					Param("repo", "flamego").
+					Param("tab", "keys").
+					WithOptional(),
+				want: "/flamego/flamego/settings/keys",
+			},
+			{
+				name:    "invalid value",
+				builder: f.URL("issue").Param("id", "abc"),
+				wantErr: `build URL path of route "issue": value "abc" of bind parameter "id" does not match /[0-9]+/`,
+			},
+			{
+				name: "query and fragment",
+				builder: f.URL("issue").
+					Param("id", "1").
+					Query("sort", "created").
+					Query("label", "bug", "help wanted").
+					Fragment("comment-1"),
+				want: "/issues/1?label=bug&label=help+wanted&sort=created#comment-1",
+			},
+			{
+				name:    "escaped value",
+				builder: f.URL("repo.settings").Param("owner", "flame go").Param("repo", "flamego"),
+				want:    "/flame%20go/flamego/settings",
+			},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got, err := test.builder.Build()
+				if test.wantErr != "" {
+					assert.EqualError(t, err, test.wantErr)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			})
+		}
+	})
+
+	t.Run("Absolute", func(t *testing.T) {
+		_, err := f.URL("issue").Param("id", "1").Absolute()
+		assert.EqualError(t, err, "base URL is not set")
+
+		f.BaseURL("https://flamego.dev/webapi/")
+		got, err := f.URL("issue").Param("id", "1").Query("page", "2").Absolute()
+		require.NoError(t, err)
+		assert.Equal(t, "https://flamego.dev/webapi/issues/1?page=2", got)
+
+		_, err = f.URL("issue").Absolute()
+		assert.EqualError(t, err, `build URL path of route "issue": missing value for bind parameter "id"`)
+	})
+}
@@ -0,0 +1,193 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	gocontext "context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"charm.land/log/v2"
+
+	"github.com/flamego/flamego/inject"
+)
+
+// requestTypes is the list of types that are mapped to every request context,
+// which must be kept in sync with newContext.
+var requestTypes = []reflect.Type{
+	inject.InterfaceOf((*Context)(nil)),
+	inject.InterfaceOf((*http.ResponseWriter)(nil)),
+	reflect.TypeOf((*http.Request)(nil)),
+	inject.InterfaceOf((*gocontext.Context)(nil)),
+}
+
+// providedTypes is the registry of types that handlers map to the request
+// context, keys are function pointers of handlers.
+var providedTypes = struct {
+	sync.RWMutex
+	types map[uintptr][]reflect.Type
+}{
+	types: make(map[uintptr][]reflect.Type),
+}
+
+// Provides declares the types that the handler maps to the request context,
+// e.g. via Context.Map, and returns the handler as-is. Types are given in the
+// same way as inject.TypeMapper, i.e. a value of the concrete type or a pointer
+// to the interface. Flame.Validate takes declared types into account for
+// handlers that come after the handler. It panics if the handler is not a
+// function.
+//
+// Declarations apply to all handlers that share the same function, including
+// closures that are created by the same function literal. For example:
+//
+//	func Authenticator() flamego.Handler {
+//	    return flamego.Provides(func(c flamego.Context) {
+//	        c.Map(&User{...})
+//	    }, (*User)(nil))
+//	}
+func Provides(h Handler, types ...interface{}) Handler {
+	v := reflect.ValueOf(h)
+	if v.Kind() != reflect.Func {
+		panic(fmt.Sprintf("handler must be a callable function, but got %T", h))
+	}
+
+	ts := make([]reflect.Type, 0, len(types))
+	for _, typ := range types {
+		t := reflect.TypeOf(typ)
+		if t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Interface {
+			t = t.Elem()
+		}
+		ts = append(ts, t)
+	}
+
+	providedTypes.Lock()
+	providedTypes.types[v.Pointer()] = append(providedTypes.types[v.Pointer()], ts...)
+	providedTypes.Unlock()
+	return h
+}
+
+// handlerProvidedTypes returns the list of types that are declared to be mapped
+// by the handler.
+func handlerProvidedTypes(h Handler) []reflect.Type {
+	v := reflect.ValueOf(h)
+	if v.Kind() != reflect.Func {
+		return nil
+	}
+
+	providedTypes.RLock()
+	defer providedTypes.RUnlock()
+	return providedTypes.types[v.Pointer()]
+}
+
+// Validate checks whether dependencies of every registered handler, including
+// global and group middleware, status handlers and the error handler, can be
+// satisfied by services of the Flame instance, services of every request (e.g.
+// Context), and types that are declared by preceding handlers via Provides. It
+// returns an error that lists all unsatisfied dependencies, or nil if none.
+//
+// NOTE: Types that are mapped by handlers without declarations cannot be
+// discovered, which results in false positives. Dependencies of providers
+// (see Flame.Provide) are not checked, their types are only checked to exist.
+func (f *Flame) Validate() error {
+	var problems []string
+	// validate checks handlers after the first `skip` handlers, which only
+	// contribute declared types. The extra types are available to all handlers.
+	validate := func(name string, handlers []Handler, skip int, extra ...reflect.Type) {
+		inj := inject.New()
+		inj.SetParent(f.Injector)
+		for _, t := range append(requestTypes[:len(requestTypes):len(requestTypes)], extra...) {
+			inj.Set(t, reflect.Zero(t))
+		}
+
+		for i, h := range handlers {
+			t := reflect.TypeOf(h)
+			if i >= skip && t.Kind() == reflect.Func {
+				for j := 0; j < t.NumIn(); j++ {
+					if !inj.Has(t.In(j)) {
+						problems = append(problems,
+							fmt.Sprintf("%s: %s handler [%s]: value not found for type %v", name, ordinalize(i-skip+1), handlerName(h), t.In(j)))
+					}
+				}
+			}
+
+			for _, pt := range handlerProvidedTypes(h) {
+				inj.Set(pt, reflect.Zero(pt))
+			}
+		}
+	}
+
+	chain := func(handlers []Handler) []Handler {
+		hs := make([]Handler, 0, len(f.handlers)+len(handlers)+1)
+		hs = append(hs, f.handlers...)
+		hs = append(hs, handlers...)
+		if f.action != nil {
+			hs = append(hs, f.action)
+		}
+		return hs
+	}
+
+	if r, ok := f.Router.(*router); ok {
+		for _, rt := range r.routes {
+			validate(rt.method+" "+rt.path, chain(rt.handlers), 0)
+		}
+		validate("NotFound", chain(r.notFoundHandlers), 0)
+		if r.methodNotAllowedHandlers != nil {
+			validate("MethodNotAllowed", chain(r.methodNotAllowedHandlers), 0)
+		}
+	}
+
+	codes := make([]int, 0, len(f.statusHandlers))
+	for code := range f.statusHandlers {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		// Status handlers are invoked within the same request context after global
+		// middleware.
+		hs := make([]Handler, 0, len(f.handlers)+len(f.statusHandlers[code]))
+		hs = append(hs, f.handlers...)
+		hs = append(hs, f.statusHandlers[code]...)
+		validate(fmt.Sprintf("StatusHandler(%d)", code), hs, len(f.handlers))
+	}
+
+	if f.errorHandler != nil {
+		// The error handler is invoked within the request context where the error
+		// is reported, with the error being mapped.
+		hs := make([]Handler, 0, len(f.handlers)+1)
+		hs = append(hs, f.handlers...)
+		hs = append(hs, f.errorHandler)
+		validate("ErrorHandler", hs, len(f.handlers), inject.InterfaceOf((*error)(nil)))
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("unsatisfied dependencies of handlers:\n\t%s", strings.Join(problems, "\n\t"))
+}
+
+// ValidateOnRun sets whether to call Validate when Run starts the HTTP server,
+// and logs unsatisfied dependencies as warnings before serving. It is disabled
+// by default because Validate reports false positives for handlers that map
+// types without declaring them via Provides, and declarations are shared by
+// all handlers of the same function.
+func (f *Flame) ValidateOnRun(enabled bool) {
+	f.validateOnRun = enabled
+}
+
+// validateBeforeRun logs unsatisfied dependencies of handlers as a warning if
+// validation on Run is enabled.
+func (f *Flame) validateBeforeRun(logger *log.Logger) {
+	if !f.validateOnRun {
+		return
+	}
+
+	if err := f.Validate(); err != nil {
+		logger.Warn("Found handlers that may fail at runtime", "error", err)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 Flamego. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package flamego
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type validateUser struct{}
+
+func validateAuthenticator() Handler {
+	return Provides(func(c Context) {
+		c.Map(&validateUser{})
+	}, (*validateUser)(nil))
+}
+
+func TestFlame_Validate(t *testing.T) {
+	t.Run("satisfied", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Use(Renderer())
+		f.Provide(func(c Context) *sql.DB { return nil })
+		f.Get("/", func(c Context, w http.ResponseWriter, r *http.Request, _ Render, _ *sql.DB) {})
+		f.Group("/admin", func() {
+			f.Get("/users", func(*validateUser) {})
+		}, validateAuthenticator())
+		f.StatusHandler(http.StatusNotFound, func(Render) {})
+		f.ErrorHandler(func(Context, error, Render) {})
+
+		assert.Nil(t, f.Validate())
+	})
+
+	t.Run("unsatisfied", func(t *testing.T) {
+		f := NewWithLogger(&bytes.Buffer{})
+		f.Use(func(*sql.DB) {})
+		f.Get("/", func(Context) {})
+		f.Group("/admin", func() {
+			f.Get("/users", validateAuthenticator(), func(*validateUser, Render) {})
+		})
+		f.Post("/users", func(*validateUser) {})
+		f.NotFound(func(Render) {})
+		f.StatusHandler(http.StatusNotFound, func(*validateUser) {})
+		f.ErrorHandler(func(error, *validateUser) {})
+
+		err := f.Validate()
+		want := `unsatisfied dependencies of handlers:
+	GET /: 1st handler [github.com/flamego/flamego.TestFlame_Validate.func2.1]: value not found for type *sql.DB
+	GET /admin/users: 1st handler [github.com/flamego/flamego.TestFlame_Validate.func2.1]: value not found for type *sql.DB
+	GET /admin/users: 3rd handler [github.com/flamego/flamego.TestFlame_Validate.func2.3.1]: value not found for type flamego.Render
+	POST /users: 1st handler [github.com/flamego/flamego.TestFlame_Validate.func2.1]: value not found for type *sql.DB
+	POST /users: 2nd handler [github.com/flamego/flamego.TestFlame_Validate.func2.4]: value not found for type *flamego.validateUser
+	NotFound: 1st handler [github.com/flamego/flamego.TestFlame_Validate.func2.1]: value not found for type *sql.DB
+	NotFound: 2nd handler [github.com/flamego/flamego.TestFlame_Validate.func2.5]: value not found for type flamego.Render
+	StatusHandler(404): 1st handler [github.com/flamego/flamego.TestFlame_Validate.func2.6]: value not found for type *flamego.validateUser
+	ErrorHandler: 1st handler [github.com/flamego/flamego.TestFlame_Validate.func2.7]: value not found for type *flamego.validateUser`
+		assert.EqualError(t, err, want)
+	})
+}
+
+func TestFlame_ValidateOnRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		want    string
+	}{
+		{
+			name:    "disabled by default",
+			enabled: false,
+			want:    "",
+		},
+		{
+			name:    "enabled",
+			enabled: true,
+			want:    "Found handlers that may fail at runtime",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			f := NewWithLogger(&buf)
+			f.Get("/", func(*sql.DB) {})
+			f.ValidateOnRun(test.enabled)
+
+			f.validateBeforeRun(f.logger)
+			if test.want == "" {
+				assert.Empty(t, buf.String())
+			} else {
+				assert.Contains(t, buf.String(), test.want)
+			}
+		})
+	}
+}